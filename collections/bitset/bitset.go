@@ -0,0 +1,160 @@
+// Package bitset implements a growable set of non-negative integers, each
+// element taking up a single bit.
+//
+// Internally it uses a slice of 64 bit words, growing it whenever a bit beyond
+// the current capacity is set. Compared to a map based set it is considerably
+// faster and smaller when the elements are dense (e.g. vertex ids).
+package bitset
+
+import (
+	"math/bits"
+)
+
+// Number of bits in a single word
+const wordSize = 64
+
+// Bit set data structure.
+type Bitset struct {
+	words []uint64
+}
+
+// Creates a new empty bit set, preallocating room for n bits.
+func New(n int) *Bitset {
+	return &Bitset{make([]uint64, (n+wordSize-1)/wordSize)}
+}
+
+// Expands the word slice so that it can hold bit i.
+func (b *Bitset) grow(i int) {
+	if need := i/wordSize + 1; need > len(b.words) {
+		if need <= cap(b.words) {
+			b.words = b.words[:need]
+		} else {
+			words := make([]uint64, need, 2*need)
+			copy(words, b.words)
+			b.words = words
+		}
+	}
+}
+
+// Sets bit i, expanding the set if necessary. Negative bits cannot be stored,
+// setting one panics.
+func (b *Bitset) Set(i int) {
+	if i < 0 {
+		panic("bitset: negative index")
+	}
+	b.grow(i)
+	b.words[i/wordSize] |= 1 << uint(i%wordSize)
+}
+
+// Clears bit i. If it was not set (or i is negative), nothing is done.
+func (b *Bitset) Clear(i int) {
+	if i >= 0 && i/wordSize < len(b.words) {
+		b.words[i/wordSize] &^= 1 << uint(i%wordSize)
+	}
+}
+
+// Checks whether bit i is set or not. Negative bits are never set.
+func (b *Bitset) Test(i int) bool {
+	if i < 0 || i/wordSize >= len(b.words) {
+		return false
+	}
+	return b.words[i/wordSize]&(1<<uint(i%wordSize)) != 0
+}
+
+// Returns the number of bits the set can currently hold without growing.
+func (b *Bitset) Len() int {
+	return len(b.words) * wordSize
+}
+
+// Returns the number of set bits (population count).
+func (b *Bitset) Count() int {
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Returns the number of set bits strictly below i.
+func (b *Bitset) Rank(i int) int {
+	if i <= 0 {
+		return 0
+	}
+	n, full := 0, i/wordSize
+	if full > len(b.words) {
+		full = len(b.words)
+	}
+	for _, w := range b.words[:full] {
+		n += bits.OnesCount64(w)
+	}
+	if full < len(b.words) && i%wordSize != 0 {
+		n += bits.OnesCount64(b.words[full] & (1<<uint(i%wordSize) - 1))
+	}
+	return n
+}
+
+// Returns the index of the first set bit at or after i. If there is none, the
+// second return value is false. A negative i searches from the start.
+func (b *Bitset) NextSet(i int) (int, bool) {
+	if i < 0 {
+		i = 0
+	}
+	x := i / wordSize
+	if x >= len(b.words) {
+		return 0, false
+	}
+	if w := b.words[x] >> uint(i%wordSize); w != 0 {
+		return i + bits.TrailingZeros64(w), true
+	}
+	for x++; x < len(b.words); x++ {
+		if b.words[x] != 0 {
+			return x*wordSize + bits.TrailingZeros64(b.words[x]), true
+		}
+	}
+	return 0, false
+}
+
+// Executes a function for every set bit, in increasing order.
+func (b *Bitset) Do(f func(int)) {
+	for x, w := range b.words {
+		for w != 0 {
+			f(x*wordSize + bits.TrailingZeros64(w))
+			w &= w - 1
+		}
+	}
+}
+
+// Sets every bit that is set in o (in place union).
+func (b *Bitset) Union(o *Bitset) {
+	if len(o.words) > 0 {
+		b.grow(len(o.words)*wordSize - 1)
+	}
+	for x, w := range o.words {
+		b.words[x] |= w
+	}
+}
+
+// Clears every bit that is not set in o (in place intersection).
+func (b *Bitset) Intersect(o *Bitset) {
+	for x := range b.words {
+		if x < len(o.words) {
+			b.words[x] &= o.words[x]
+		} else {
+			b.words[x] = 0
+		}
+	}
+}
+
+// Returns an independent copy of the bit set.
+func (b *Bitset) Clone() *Bitset {
+	words := make([]uint64, len(b.words))
+	copy(words, b.words)
+	return &Bitset{words}
+}
+
+// Clears the contents of the bit set, keeping the allocated capacity.
+func (b *Bitset) Reset() {
+	for x := range b.words {
+		b.words[x] = 0
+	}
+}
@@ -0,0 +1,166 @@
+package bitset
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBitset(t *testing.T) {
+	// Create some random data and a reference map
+	size := 65536
+	bits := New(0)
+	ref := make(map[int]bool)
+	for i := 0; i < size/4; i++ {
+		n := rand.Intn(size)
+		bits.Set(n)
+		ref[n] = true
+	}
+	for i := 0; i < size; i++ {
+		if bits.Test(i) != ref[i] {
+			t.Errorf("test mismatch for %v: have %v, want %v.", i, bits.Test(i), ref[i])
+		}
+	}
+	if bits.Count() != len(ref) {
+		t.Errorf("count mismatch: have %v, want %v.", bits.Count(), len(ref))
+	}
+	// Clear half the data and verify
+	for n := range ref {
+		if n%2 == 0 {
+			bits.Clear(n)
+			delete(ref, n)
+		}
+	}
+	rank := 0
+	for i := 0; i < size; i++ {
+		if bits.Test(i) != ref[i] {
+			t.Errorf("test mismatch after clear for %v: have %v, want %v.", i, bits.Test(i), ref[i])
+		}
+		if bits.Rank(i) != rank {
+			t.Errorf("rank mismatch for %v: have %v, want %v.", i, bits.Rank(i), rank)
+		}
+		if ref[i] {
+			rank++
+		}
+	}
+	// Ranks outside the set are clamped to either end
+	if bits.Rank(-1) != 0 || bits.Rank(-10*size) != 0 {
+		t.Errorf("rank mismatch below zero: have %v, want 0.", bits.Rank(-1))
+	}
+	if bits.Rank(10*size) != rank {
+		t.Errorf("rank mismatch past the end: have %v, want %v.", bits.Rank(10*size), rank)
+	}
+	// Clearing or testing out of range must not panic nor grow
+	bits.Clear(10 * size)
+	if bits.Test(10*size) || bits.Len() > 2*size {
+		t.Errorf("out of range access modified the set: len %v.", bits.Len())
+	}
+	// Negative bits are never set, clearing them is a no-op and setting them panics
+	count := bits.Count()
+	bits.Clear(-1)
+	if bits.Test(-1) || bits.Test(-wordSize) || bits.Count() != count {
+		t.Errorf("negative access modified the set: count %v, want %v.", bits.Count(), count)
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("setting a negative bit did not panic.")
+			}
+		}()
+		bits.Set(-1)
+	}()
+}
+
+func TestNextSet(t *testing.T) {
+	bits := New(1024)
+	want := []int{0, 3, 63, 64, 65, 127, 128, 700, 1023}
+	for _, i := range want {
+		bits.Set(i)
+	}
+	have := []int{}
+	for i, ok := bits.NextSet(0); ok; i, ok = bits.NextSet(i + 1) {
+		have = append(have, i)
+	}
+	if len(have) != len(want) {
+		t.Fatalf("iteration length mismatch: have %v, want %v.", have, want)
+	}
+	for i := range want {
+		if have[i] != want[i] {
+			t.Errorf("iteration mismatch: have %v, want %v.", have, want)
+		}
+	}
+	done := []int{}
+	bits.Do(func(i int) {
+		done = append(done, i)
+	})
+	for i := range want {
+		if done[i] != want[i] {
+			t.Errorf("do mismatch: have %v, want %v.", done, want)
+		}
+	}
+	if i, ok := bits.NextSet(-100); !ok || i != 0 {
+		t.Errorf("next set from a negative index: have %v, want 0.", i)
+	}
+	if _, ok := bits.NextSet(1024); ok {
+		t.Errorf("found set bit past the end")
+	}
+}
+
+func TestUnionIntersect(t *testing.T) {
+	a, b := New(0), New(0)
+	for i := 0; i < 1000; i++ {
+		if i%2 == 0 {
+			a.Set(i)
+		}
+		if i%3 == 0 {
+			b.Set(i * 2)
+		}
+	}
+	u := a.Clone()
+	u.Union(b)
+	x := a.Clone()
+	x.Intersect(b)
+	for i := 0; i < 2000; i++ {
+		if u.Test(i) != (a.Test(i) || b.Test(i)) {
+			t.Errorf("union mismatch for %v.", i)
+		}
+		if x.Test(i) != (a.Test(i) && b.Test(i)) {
+			t.Errorf("intersection mismatch for %v.", i)
+		}
+	}
+	// The clones must not have touched the original
+	if !a.Test(998) || a.Test(1998) || a.Count() != 500 {
+		t.Errorf("original modified: count %v.", a.Count())
+	}
+}
+
+func TestReset(t *testing.T) {
+	bits := New(128)
+	for i := 0; i < 128; i++ {
+		bits.Set(i)
+	}
+	bits.Reset()
+	if bits.Count() != 0 {
+		t.Errorf("bitset not empty after reset: %v.", bits.Count())
+	}
+	if bits.Len() != 128 {
+		t.Errorf("capacity lost after reset: have %v, want %v.", bits.Len(), 128)
+	}
+}
+
+func BenchmarkSet(b *testing.B) {
+	bits := New(0)
+	for i := 0; i < b.N; i++ {
+		bits.Set(i)
+	}
+}
+
+func BenchmarkTest(b *testing.B) {
+	bits := New(b.N)
+	for i := 0; i < b.N; i += 3 {
+		bits.Set(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bits.Test(i)
+	}
+}
@@ -0,0 +1,29 @@
+package bitset_test
+
+import (
+	"fmt"
+
+	"github.com/cc14514/go-cookiekit/collections/bitset"
+)
+
+// Mark a few numbers in a bit set and walk over them in increasing order.
+func Example_usage() {
+	// Create a bit set and mark some numbers
+	b := bitset.New(64)
+	for _, i := range []int{42, 3, 100, 7} {
+		b.Set(i)
+	}
+	b.Clear(7)
+
+	// Iterate over the set bits
+	for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+		fmt.Println(i)
+	}
+	fmt.Println("Count:", b.Count(), "Rank of 100:", b.Rank(100))
+
+	// Output:
+	// 3
+	// 42
+	// 100
+	// Count: 3 Rank of 100: 2
+}
@@ -1,12 +1,30 @@
 package graph
 
 import (
-	"github.com/cc14514/go-cookiekit/collections/bag"
+	"github.com/cc14514/go-cookiekit/collections/bitset"
 	"github.com/cc14514/go-cookiekit/collections/queue"
 	"github.com/cc14514/go-cookiekit/collections/stack"
-	"fmt"
 )
 
+// 迭代深度优先遍历时的栈帧, 用显式栈代替递归调用,
+// 避免路径很长的图把 goroutine 栈撑爆
+type dfsFrame struct {
+	v   int   // 当前展开的顶点
+	u   int   // 上一个顶点, 即 v 的父节点
+	adj []int // v 的邻接表
+	i   int   // 下一个要访问的 adj 下标
+}
+
+// 取出下一个邻接顶点, 没有则返回 false
+func (self *dfsFrame) next() (int, bool) {
+	if self.i >= len(self.adj) {
+		return 0, false
+	}
+	w := self.adj[self.i]
+	self.i++
+	return w, true
+}
+
 // =======================
 // 无向图 API
 // =======================
@@ -14,46 +32,44 @@ import (
 // 深度优先 Depth First Search
 type DFSearch struct {
 	count  int
-	s      int      // 起点 s
-	marked *bag.Bag // 与 s 连通的顶点集合
-	edgeTo []int    // 边的映射,用来寻找路径
+	marked *bitset.Bitset // 与起点连通的顶点集合
+	edgeTo []int          // 边的映射,用来寻找路径, 起点指向自己
 }
 
 func (self *DFSearch) PathTo(v int) []int {
-	if !self.Marked(v) {
-		return nil
-	}
-	sk := stack.New()
-	for x := v; x != self.s; x = self.edgeTo[x] {
-		sk.Push(x)
-	}
-	sk.Push(self.s)
-	result := make([]int, 0)
-	for !sk.Empty() {
-		result = append(result, sk.Pop().(int))
-	}
-	return result
+	return pathTo(self.marked, self.edgeTo, v)
 }
 
 func (self *DFSearch) GenSearch(graph SimpleGraph, s int) Search {
-	self.count, self.s, self.marked, self.edgeTo = 0, s, bag.New(), make([]int, graph.V())
-	self.dfs(graph, s)
+	self.GenMultiSearch(graph, s)
 	return self
 }
 
-func (self *DFSearch) dfs(graph SimpleGraph, v int) {
-	self.marked.Insert(v)
-	self.count ++
-	for _, w := range graph.Adj(v) {
-		if self.marked.Count(w) < 1 {
-			self.edgeTo[w] = v
-			self.dfs(graph, w)
-		}
+// 多点可达性, 从 sources 中任意一个起点出发能到达的顶点
+func (self *DFSearch) GenMultiSearch(graph SimpleGraph, sources ...int) MultiSearch {
+	self.count, self.marked, self.edgeTo = 0, bitset.New(graph.V()), make([]int, graph.V())
+	if len(sources) == 0 {
+		return self
+	}
+	for _, s := range sources {
+		self.edgeTo[s] = s
 	}
+	DFSVisit(graph, &VisitorFuncs{
+		OnDiscoverVertex: func(v int) VisitResult {
+			self.marked.Set(v)
+			self.count++
+			return VisitContinue
+		},
+		OnTreeEdge: func(v, w int) VisitResult {
+			self.edgeTo[w] = v
+			return VisitContinue
+		},
+	}, sources...)
+	return self
 }
 
 func (self *DFSearch) Marked(v int) bool {
-	return self.marked.Count(v) > 0
+	return self.marked.Test(v)
 }
 
 func (self *DFSearch) Count() int {
@@ -61,60 +77,122 @@ func (self *DFSearch) Count() int {
 }
 
 // 广度优先 Breadth First Search
+// 得到的路径都是边数最少的路径
 type BFSearch struct {
 	DFSearch
+	distTo   []int // 到最近起点的边数
+	sourceOf []int // 到达顶点的起点
 }
 
 func (self *BFSearch) GenSearch(graph SimpleGraph, s int) Search {
-	self.count, self.s, self.marked, self.edgeTo = 0, s, bag.New(), make([]int, graph.V())
-	self.bfs(graph, s)
+	self.GenMultiSearch(graph, s)
+	return self
+}
+
+// 多源广度优先, 所有起点同时出发, 每个顶点记录离它最近的起点
+func (self *BFSearch) GenMultiSearch(graph SimpleGraph, sources ...int) MultiSearch {
+	self.count, self.marked, self.edgeTo = 0, bitset.New(graph.V()), make([]int, graph.V())
+	self.distTo, self.sourceOf = newDistTo(graph.V()), newDistTo(graph.V())
+	self.bfs(graph, sources)
 	return self
 }
 
-func (self *BFSearch) bfs(graph SimpleGraph, s int) {
+func (self *BFSearch) bfs(graph SimpleGraph, sources []int) {
 	q := queue.New()
-	self.marked.Insert(s)
-	self.count ++
-	q.Push(s)
+	for _, s := range sources {
+		if !self.Marked(s) {
+			self.marked.Set(s)
+			self.count++
+			self.edgeTo[s], self.distTo[s], self.sourceOf[s] = s, 0, s
+			q.Push(s)
+		}
+	}
 	for !q.Empty() {
 		v := q.Pop()
 		for _, a := range graph.Adj(v.(int)) {
 			if !self.Marked(a) {
-				self.marked.Insert(a)
+				self.marked.Set(a)
 				self.count ++
 				self.edgeTo[a] = v.(int)
+				self.distTo[a] = self.distTo[v.(int)] + 1
+				self.sourceOf[a] = self.sourceOf[v.(int)]
 				q.Push(a)
 			}
 		}
 	}
 }
 
+func (self *BFSearch) DistTo(v int) int {
+	return self.distTo[v]
+}
+
+func (self *BFSearch) SourceOf(v int) int {
+	return self.sourceOf[v]
+}
+
+// 沿着 edgeTo 从 v 回溯到起点, 起点的 edgeTo 指向自己
+func pathTo(marked *bitset.Bitset, edgeTo []int, v int) []int {
+	if !marked.Test(v) {
+		return nil
+	}
+	sk := stack.New()
+	x := v
+	for ; edgeTo[x] != x; x = edgeTo[x] {
+		sk.Push(x)
+	}
+	sk.Push(x)
+	result := make([]int, 0)
+	for !sk.Empty() {
+		result = append(result, sk.Pop().(int))
+	}
+	return result
+}
+
+// 初始化为 -1 (不可达) 的数组
+func newDistTo(n int) []int {
+	r := make([]int, n)
+	for i := range r {
+		r[i] = -1
+	}
+	return r
+}
+
 type CCImpl struct {
-	marked *bag.Bag
+	marked *bitset.Bitset
 	count  int
 	id     []int
 }
 
 func NewCC(graph SimpleGraph) CC {
 	cc := new(CCImpl)
-	cc.marked = bag.New()
+	cc.marked = bitset.New(graph.V())
 	cc.count = 0
 	cc.id = make([]int, graph.V())
+	sk := stack.New()
 	for v, _ := range graph.GetAdj() {
-		if cc.marked.Count(v) < 1 {
-			cc.dfs(graph, v)
+		if !cc.marked.Test(v) {
+			cc.dfs(graph, v, sk)
 			cc.count ++
 		}
 	}
 	return cc
 }
 
-func (self *CCImpl) dfs(graph SimpleGraph, v int) {
-	self.marked.Insert(v)
-	self.id[v] = self.count
-	for _, w := range graph.Adj(v) {
-		if self.marked.Count(w) < 1 {
-			self.dfs(graph, w)
+func (self *CCImpl) dfs(graph SimpleGraph, s int, sk *stack.Stack) {
+	self.marked.Set(s)
+	self.id[s] = self.count
+	sk.Push(&dfsFrame{v: s, adj: graph.Adj(s)})
+	for !sk.Empty() {
+		f := sk.Top().(*dfsFrame)
+		w, ok := f.next()
+		if !ok {
+			sk.Pop()
+			continue
+		}
+		if !self.marked.Test(w) {
+			self.marked.Set(w)
+			self.id[w] = self.count
+			sk.Push(&dfsFrame{v: w, adj: graph.Adj(w)})
 		}
 	}
 }
@@ -134,37 +212,44 @@ func (self *CCImpl) ID(v int) int {
 // 无向图 Cycle : 深度优先, 判断是否包含环
 // 前提是没有平行边和自环
 type CycleImpl struct {
-	marked  *bag.Bag // 与 s 连通的顶点集合
+	marked  *bitset.Bitset // 与 s 连通的顶点集合
 	isCycle bool
 	edgeTo  []int
 	cycle   []int
 }
 
 func NewCycle(graph SimpleGraph) Cycle {
-	c := &CycleImpl{bag.New(), false, make([]int, graph.V()), nil}
+	c := &CycleImpl{bitset.New(graph.V()), false, make([]int, graph.V()), nil}
 	// 因为图未必是全连同图，所以每条边都要深度遍历一次，
 	// 因为有 marked 的存在会过滤掉重复的子图
+	sk := stack.New()
 	for k, _ := range graph.GetAdj() {
-		if c.marked.Count(k) < 1 {
-			c.dfs(graph, k, k)
+		if !c.marked.Test(k) {
+			c.dfs(graph, k, k, sk)
 		}
 	}
 	return c
 }
 
 //graph 是图对象
-//v 要展开的顶点
-//u 上次调用此方法的 v
-func (self *CycleImpl) dfs(graph SimpleGraph, v, u int) {
-	self.marked.Insert(v)
-	for _, a := range graph.Adj(v) {
-		if self.HasCycle() {
-			return
+//s 要展开的顶点
+//u 上一个展开的顶点, 起点的 u 就是它自己
+func (self *CycleImpl) dfs(graph SimpleGraph, s, u int, sk *stack.Stack) {
+	self.marked.Set(s)
+	sk.Push(&dfsFrame{v: s, u: u, adj: graph.Adj(s)})
+	for !sk.Empty() && !self.HasCycle() {
+		f := sk.Top().(*dfsFrame)
+		a, ok := f.next()
+		if !ok {
+			sk.Pop()
+			continue
 		}
-		if self.marked.Count(a) < 1 {
+		v := f.v
+		if !self.marked.Test(a) {
 			self.edgeTo[a] = v // 记录遍历路径 a <- v
-			self.dfs(graph, a, v)
-		} else if a != u {
+			self.marked.Set(a)
+			sk.Push(&dfsFrame{v: a, u: v, adj: graph.Adj(a)})
+		} else if a != f.u {
 			// 顺着邻接表的一个顶点开始深度优先遍历
 			// 如果存在一个顶点被标记过，但并非上一个顶点，则一定存在环
 			self.isCycle = true
@@ -176,6 +261,10 @@ func (self *CycleImpl) dfs(graph SimpleGraph, v, u int) {
 			self.cycle = append(self.cycle, v)
 		}
 	}
+	// 找到环时会提前退出, 清空栈留给下一个连通分量使用
+	for !sk.Empty() {
+		sk.Pop()
+	}
 }
 func (self *CycleImpl) Cycle() []int {
 	if !self.HasCycle() {
@@ -191,7 +280,7 @@ func (self *CycleImpl) HasCycle() bool {
 // 二分图 TowColor
 // 无向图G为二分图的充分必要条件是，G至少有两个顶点，且其所有回路的长度均为偶数
 type TowColorImpl struct {
-	marked      *bag.Bag // 与 s 连通的顶点集合
+	marked      *bitset.Bitset // 与 s 连通的顶点集合
 	color       []bool
 	isBipartite bool
 }
@@ -199,23 +288,36 @@ type TowColorImpl struct {
 func NewTowColor(graph SimpleGraph) TowColor {
 	tc := new(TowColorImpl)
 	tc.isBipartite = true
-	tc.marked = bag.New()
+	tc.marked = bitset.New(graph.V())
 	tc.color = make([]bool, graph.V())
+	sk := stack.New()
 	for v, _ := range graph.GetAdj() {
-		tc.dfs(graph, v)
+		// 已标记的顶点所在的连通分量已经检查过了
+		if !tc.marked.Test(v) {
+			tc.dfs(graph, v, sk)
+		}
 	}
 	return tc
 }
 
 //graph 是图对象
-//v 要展开的顶点
-func (self *TowColorImpl) dfs(graph SimpleGraph, v int) {
-	self.marked.Insert(v)
-	for _, a := range graph.Adj(v) {
-		if self.marked.Count(a) < 1 {
+//s 要展开的顶点
+func (self *TowColorImpl) dfs(graph SimpleGraph, s int, sk *stack.Stack) {
+	self.marked.Set(s)
+	sk.Push(&dfsFrame{v: s, adj: graph.Adj(s)})
+	for !sk.Empty() {
+		f := sk.Top().(*dfsFrame)
+		a, ok := f.next()
+		if !ok {
+			sk.Pop()
+			continue
+		}
+		v := f.v
+		if !self.marked.Test(a) {
 			// 和 a 相邻的节点必须跟 a 是相反的颜色
 			self.color[a] = !self.color[v]
-			self.dfs(graph, a)
+			self.marked.Set(a)
+			sk.Push(&dfsFrame{v: a, adj: graph.Adj(a)})
 		} else if self.color[a] == self.color[v] {
 			// 顺着邻接表的一个顶点开始深度优先遍历
 			// 如果存在一个顶点被标记过，但是跟我颜色相同，则断言一定不是二分图
@@ -228,6 +330,11 @@ func (self *TowColorImpl) IsBipartite() bool {
 	return self.isBipartite
 }
 
+// v 的颜色, 是二分图时每条边两端的颜色不同
+func (self *TowColorImpl) Color(v int) bool {
+	return self.color[v]
+}
+
 // =======================
 // 有向图 API
 // =======================
@@ -236,13 +343,12 @@ func (self *TowColorImpl) IsBipartite() bool {
 type DirectedSearchDFS struct {
 	count int
 	// 单点可达性、多点可达性
-	s      int      // 起点 ss
-	marked *bag.Bag // 与 ss 连通的顶点集合
-	edgeTo []int    // 边的映射,用来寻找路径
+	marked *bitset.Bitset // 与起点连通的顶点集合
+	edgeTo []int          // 边的映射,用来寻找路径, 起点指向自己
 }
 
 func (self *DirectedSearchDFS) Marked(v int) bool {
-	b := self.marked.Count(v) > 0
+	b := self.marked.Test(v)
 	return b
 }
 
@@ -251,115 +357,120 @@ func (self *DirectedSearchDFS) Count() int {
 }
 
 func (self *DirectedSearchDFS) PathTo(v int) []int {
-	if !self.Marked(v) {
-		return nil
-	}
-	sk := stack.New()
-	for x := v; x != self.s; x = self.edgeTo[x] {
-		sk.Push(x)
-	}
-	sk.Push(self.s)
-	r := make([]int, 0)
-	for !sk.Empty() {
-		r = append(r, sk.Pop().(int))
-	}
-	return r
+	return pathTo(self.marked, self.edgeTo, v)
 }
 
 func (self *DirectedSearchDFS) GenSearch(digraph SimpleDigraph, s int) DirectedSearch {
-	self.count, self.s, self.marked, self.edgeTo = 0, s, bag.New(), make([]int, digraph.V())
-	self.dfs(digraph, s)
+	self.GenMultiSearch(digraph, s)
 	return self
 }
 
-func (self *DirectedSearchDFS) dfs(digraph SimpleDigraph, s int) {
-	self.marked.Insert(s)
-	self.count ++
-	for _, v := range digraph.Adj(s) {
-		if !self.Marked(v) {
-			self.edgeTo[v] = s
-			self.dfs(digraph, v)
-		}
+// 多点可达性, 从 sources 中任意一个起点出发能到达的顶点
+func (self *DirectedSearchDFS) GenMultiSearch(digraph SimpleDigraph, sources ...int) DirectedMultiSearch {
+	self.count, self.marked, self.edgeTo = 0, bitset.New(digraph.V()), make([]int, digraph.V())
+	if len(sources) == 0 {
+		return self
+	}
+	for _, s := range sources {
+		self.edgeTo[s] = s
 	}
+	DirectedDFSVisit(digraph, &VisitorFuncs{
+		OnDiscoverVertex: func(v int) VisitResult {
+			self.marked.Set(v)
+			self.count++
+			return VisitContinue
+		},
+		OnTreeEdge: func(v, w int) VisitResult {
+			self.edgeTo[w] = v
+			return VisitContinue
+		},
+	}, sources...)
+	return self
 }
 
 // 广度优先 Breadth First Search
+// 得到的路径都是边数最少的路径
 type DirectedSearchBFS struct {
 	DirectedSearchDFS
+	distTo   []int // 到最近起点的边数
+	sourceOf []int // 到达顶点的起点
 }
 
 func (self *DirectedSearchBFS) GenSearch(graph SimpleDigraph, s int) DirectedSearch {
-	self.count, self.s, self.marked, self.edgeTo = 0, s, bag.New(), make([]int, graph.V())
-	self.bfs(graph, s)
+	self.GenMultiSearch(graph, s)
+	return self
+}
+
+// 多源广度优先, 所有起点同时出发, 每个顶点记录离它最近的起点
+func (self *DirectedSearchBFS) GenMultiSearch(graph SimpleDigraph, sources ...int) DirectedMultiSearch {
+	self.count, self.marked, self.edgeTo = 0, bitset.New(graph.V()), make([]int, graph.V())
+	self.distTo, self.sourceOf = newDistTo(graph.V()), newDistTo(graph.V())
+	self.bfs(graph, sources)
 	return self
 }
 
-func (self *DirectedSearchBFS) bfs(graph SimpleDigraph, s int) {
+func (self *DirectedSearchBFS) bfs(graph SimpleDigraph, sources []int) {
 	q := queue.New()
-	self.marked.Insert(s)
-	self.count ++
-	q.Push(s)
+	for _, s := range sources {
+		if !self.Marked(s) {
+			self.marked.Set(s)
+			self.count++
+			self.edgeTo[s], self.distTo[s], self.sourceOf[s] = s, 0, s
+			q.Push(s)
+		}
+	}
 	for !q.Empty() {
 		v := q.Pop()
 		for _, a := range graph.Adj(v.(int)) {
 			if !self.Marked(a) {
-				self.marked.Insert(a)
+				self.marked.Set(a)
 				self.count ++
 				self.edgeTo[a] = v.(int)
+				self.distTo[a] = self.distTo[v.(int)] + 1
+				self.sourceOf[a] = self.sourceOf[v.(int)]
 				q.Push(a)
 			}
 		}
 	}
 }
 
+func (self *DirectedSearchBFS) DistTo(v int) int {
+	return self.distTo[v]
+}
+
+func (self *DirectedSearchBFS) SourceOf(v int) int {
+	return self.sourceOf[v]
+}
+
 // 有向图 Cycle : 深度优先, 判断是否包含环
 // 前提是没有平行边和自环
 type DirectedCycleImpl struct {
-	marked  *bag.Bag
 	isCycle bool
 	edgeTo  []int
 	cycle   []int
-	onStack []bool
 }
 
 func NewDirectedCycle(digraph SimpleDigraph) Cycle {
 	dc := new(DirectedCycleImpl)
 	dc.edgeTo = make([]int, digraph.V())
-	dc.onStack = make([]bool, digraph.V())
-	dc.marked = bag.New()
-	for v, _ := range digraph.GetAdj() {
-		if dc.marked.Count(v) < 1 {
-			dc.dfs(digraph, v)
-		}
-	}
-	return dc
-}
-
-func (self *DirectedCycleImpl) dfs(digraph SimpleDigraph, v int) {
-	self.onStack[v] = true
-	defer func() {
-		self.onStack[v] = false
-	}()
-	self.marked.Insert(v)
-	for _, w := range digraph.Adj(v) {
-		if self.HasCycle() {
-			return
-		}
-		if self.marked.Count(w) < 1 {
-			self.edgeTo[w] = v //记录路径 w <- v
-			self.dfs(digraph, w)
-		} else if self.onStack[w] {
-			//如果当前节点在递归栈中，并且已经被标记过了，那这就是一个有向环了
-			self.isCycle = true
-			self.cycle = make([]int, 0)
-			for x := v; x != w; x = self.edgeTo[x] {
-				self.cycle = append(self.cycle, x)
+	DirectedDFSVisit(digraph, &VisitorFuncs{
+		OnTreeEdge: func(v, w int) VisitResult {
+			dc.edgeTo[w] = v //记录路径 w <- v
+			return VisitContinue
+		},
+		OnBackEdge: func(v, w int) VisitResult {
+			//回边指向的顶点还在递归栈中，那这就是一个有向环了
+			dc.isCycle = true
+			dc.cycle = make([]int, 0)
+			for x := v; x != w; x = dc.edgeTo[x] {
+				dc.cycle = append(dc.cycle, x)
 			}
-			self.cycle = append(self.cycle, w)
-			self.cycle = append(self.cycle, v)
-		}
-	}
-	self.marked.Insert(v)
+			dc.cycle = append(dc.cycle, w)
+			dc.cycle = append(dc.cycle, v)
+			return VisitStop
+		},
+	})
+	return dc
 }
 
 func (self *DirectedCycleImpl) HasCycle() bool {
@@ -375,7 +486,6 @@ type DFOrder struct {
 	per         []int
 	post        []int
 	reversePost []int
-	marked      *bag.Bag
 }
 
 func NewDFOrder(dig SimpleDigraph) DigOrder {
@@ -383,30 +493,24 @@ func NewDFOrder(dig SimpleDigraph) DigOrder {
 	o.per = make([]int, 0)
 	o.post = make([]int, 0)
 	o.reversePost = make([]int, 0)
-	o.marked = bag.New()
-	for v, _ := range dig.GetAdj() {
-		if o.marked.Count(v) < 1 {
-			o.dfs(dig, v)
-		}
+	DirectedDFSVisit(dig, &VisitorFuncs{
+		OnDiscoverVertex: func(v int) VisitResult {
+			o.per = append(o.per, v)
+			return VisitContinue
+		},
+		OnFinishVertex: func(v int) VisitResult {
+			// 所有邻接顶点都处理完了, v 出栈时记录后序
+			o.post = append(o.post, v)
+			return VisitContinue
+		},
+	})
+	// 逆后序就是后序倒过来, 最后一次性生成, 避免每次往头部插入
+	for i := len(o.post) - 1; i >= 0; i-- {
+		o.reversePost = append(o.reversePost, o.post[i])
 	}
 	return o
 }
 
-func (self *DFOrder) dfs(dig SimpleDigraph, v int) {
-	self.marked.Insert(v)
-	self.per = append(self.per, v)
-	defer func() {
-		self.post = append(self.post, v)
-		self.reversePost = append([]int{v}, self.reversePost[:]...)
-	}()
-	for _, w := range dig.Adj(v) {
-		if self.marked.Count(w) < 1 {
-			self.dfs(dig, w)
-		}
-
-	}
-}
-
 func (self *DFOrder) Per() []int {
 	return self.per
 }
@@ -432,8 +536,6 @@ func NewDigTopological(dig SimpleDigraph) Topological {
 	if digt.isDAG {
 		o := NewDFOrder(dig)
 		digt.order = o.ReversePost()
-		fmt.Println("p", o.Post())
-		fmt.Println("r", o.ReversePost())
 	}
 	return digt
 }
@@ -2,6 +2,9 @@ package graph
 
 import (
 	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"runtime/debug"
+	"sort"
 	"testing"
 )
 
@@ -200,3 +203,189 @@ func TestNewDigTopological(t *testing.T) {
 	t.Log(tl.IsDAG())
 	t.Log(tl.Order())
 }
+
+// 邻接表按顺序返回的有向图, 保证遍历顺序是确定的
+type sortedDigraph struct {
+	*Digraph
+}
+
+func (self sortedDigraph) Adj(v int) []int {
+	r := self.Digraph.Adj(v)
+	sort.Ints(r)
+	return r
+}
+
+// 递归版本的深度优先排序, 用来校验迭代版本的前序和后序
+func recursiveOrder(dig SimpleDigraph) (per, post []int) {
+	marked := make([]bool, dig.V())
+	var dfs func(v int)
+	dfs = func(v int) {
+		marked[v] = true
+		per = append(per, v)
+		for _, w := range dig.Adj(v) {
+			if !marked[w] {
+				dfs(w)
+			}
+		}
+		post = append(post, v)
+	}
+	for v := 0; v < dig.V(); v++ {
+		if !marked[v] {
+			dfs(v)
+		}
+	}
+	return
+}
+
+func TestDFOrderSemantics(t *testing.T) {
+	d := sortedDigraph{dag.(*Digraph)}
+	per, post := recursiveOrder(d)
+	o := NewDFOrder(d)
+	assert.Equal(t, per, o.Per())
+	assert.Equal(t, post, o.Post())
+	for i, v := range o.ReversePost() {
+		assert.Equal(t, post[len(post)-1-i], v)
+	}
+}
+
+// 大图回归测试: 限制 goroutine 栈的大小, 递归实现会在这里栈溢出
+const largeGraphSize = 1 << 18
+
+func limitStack(t *testing.T) {
+	old := debug.SetMaxStack(4 << 20)
+	t.Cleanup(func() { debug.SetMaxStack(old) })
+}
+
+func largePath() *Graph {
+	g := NewGraph(largeGraphSize)
+	for v := 0; v+1 < largeGraphSize; v++ {
+		g.AddEdge(v, v+1)
+	}
+	return g
+}
+
+func largeDirectedPath() *Digraph {
+	d := NewDigraph(largeGraphSize)
+	for v := 0; v+1 < largeGraphSize; v++ {
+		d.AddEdge(v, v+1)
+	}
+	return d
+}
+
+func TestLargeGraphSearch(t *testing.T) {
+	limitStack(t)
+	path := largePath()
+	search := new(DFSearch).GenSearch(path, 0)
+	assert.Equal(t, largeGraphSize, search.Count())
+	assert.Equal(t, largeGraphSize, len(search.PathTo(largeGraphSize-1)))
+
+	cc := NewCC(path)
+	assert.Equal(t, 1, cc.Count())
+	assert.True(t, cc.Connected(0, largeGraphSize-1))
+
+	assert.False(t, NewCycle(path).HasCycle())
+	assert.True(t, NewTowColor(path).IsBipartite())
+
+	path.AddEdge(largeGraphSize-1, 0)
+	c := NewCycle(path)
+	assert.True(t, c.HasCycle())
+	assert.Equal(t, largeGraphSize+1, len(c.Cycle()))
+}
+
+func TestLargeDigraphSearch(t *testing.T) {
+	limitStack(t)
+	path := largeDirectedPath()
+	search := new(DirectedSearchDFS).GenSearch(path, 0)
+	assert.Equal(t, largeGraphSize, search.Count())
+
+	assert.False(t, NewDirectedCycle(path).HasCycle())
+	o := NewDFOrder(path)
+	per, post, reversePost := o.Per(), o.Post(), o.ReversePost()
+	for i := 0; i < largeGraphSize; i++ {
+		if per[i] != i || post[i] != largeGraphSize-1-i || reversePost[i] != i {
+			t.Fatalf("order mismatch at %d: per %d, post %d, reverse post %d", i, per[i], post[i], reversePost[i])
+		}
+	}
+
+	path.AddEdge(largeGraphSize-1, 0)
+	c := NewDirectedCycle(path)
+	assert.True(t, c.HasCycle())
+	assert.Equal(t, largeGraphSize+1, len(c.Cycle()))
+}
+
+// 基准测试用的图: 一条路径再加上一些随机边, 保证每个顶点都有邻接表
+func benchGraph(v, e int) *Graph {
+	r := rand.New(rand.NewSource(1))
+	g := NewGraph(v)
+	for i := 0; i+1 < v; i++ {
+		g.AddEdge(i, i+1)
+	}
+	for g.E() < e {
+		g.AddEdge(r.Intn(v), r.Intn(v))
+	}
+	return g
+}
+
+func BenchmarkBFSearch(b *testing.B) {
+	g := benchGraph(100000, 500000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		new(BFSearch).GenSearch(g, 0)
+	}
+}
+
+func BenchmarkNewCC(b *testing.B) {
+	g := benchGraph(100000, 500000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewCC(g)
+	}
+}
+
+func TestMultiSearch(t *testing.T) {
+	g := NewGraph(10)
+	for v := 0; v+1 < 10; v++ {
+		g.AddEdge(v, v+1)
+	}
+	bfs := new(BFSearch)
+	bfs.GenMultiSearch(g, 0, 9)
+	assert.Equal(t, 10, bfs.Count())
+	assert.Equal(t, 4, bfs.DistTo(4))
+	assert.Equal(t, 0, bfs.SourceOf(4))
+	assert.Equal(t, 3, bfs.DistTo(6))
+	assert.Equal(t, 9, bfs.SourceOf(6))
+	assert.Equal(t, []int{9, 8, 7, 6}, bfs.PathTo(6))
+	assert.Equal(t, []int{0}, bfs.PathTo(0))
+
+	search := new(DFSearch).GenMultiSearch(NewGraphByData(data1), 0, 4)
+	assert.Equal(t, 6, search.Count())
+	search = new(DFSearch).GenMultiSearch(NewGraphByData(data1))
+	assert.Equal(t, 0, search.Count())
+}
+
+func TestDirectedMultiSearch(t *testing.T) {
+	d := NewDigraph(7)
+	d.AddEdge(0, 1)
+	d.AddEdge(1, 2)
+	d.AddEdge(2, 3)
+	d.AddEdge(4, 5)
+	d.AddEdge(5, 3)
+	bfs := new(DirectedSearchBFS)
+	bfs.GenMultiSearch(d, 0, 4)
+	assert.Equal(t, 6, bfs.Count())
+	assert.False(t, bfs.Marked(6))
+	assert.Equal(t, -1, bfs.DistTo(6))
+	assert.Equal(t, -1, bfs.SourceOf(6))
+	assert.Equal(t, 2, bfs.DistTo(3))
+	assert.Equal(t, 4, bfs.SourceOf(3))
+	assert.Equal(t, []int{4, 5, 3}, bfs.PathTo(3))
+	assert.Nil(t, bfs.PathTo(6))
+
+	dfs := new(DirectedSearchDFS).GenMultiSearch(d, 1, 5)
+	assert.Equal(t, 4, dfs.Count())
+	assert.False(t, dfs.Marked(0))
+	assert.Equal(t, 3, dfs.PathTo(3)[len(dfs.PathTo(3))-1])
+
+	var _ BFSPaths = bfs
+	var _ BFSPaths = new(BFSearch)
+}
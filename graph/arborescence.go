@@ -0,0 +1,160 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Chu–Liu/Edmonds 最小树形图
+// 每个非根顶点先选权重最小的入边, 没有环就是答案; 否则把每个环收缩成一个顶点,
+// 指向环上顶点 v 的边的权重减去 v 选中的入边的权重 (换掉这条边的代价), 在收缩后的图上继续求解,
+// 展开时环上除了被新选中的入边指向的顶点以外, 其余顶点保留原来选的入边
+// 用显式的层次代替递归, 时间复杂度 O(VE)
+type ArborescenceImpl struct {
+	root   int
+	parent []int
+	edges  []DirectedEdge
+	weight float64
+}
+
+// 收缩过程中的边, prev 是它在上一层中的编号
+type arbEdge struct {
+	u, v int
+	w    float64
+	prev int
+}
+
+type arbLevel struct {
+	edges  []arbEdge
+	in     []int   // 每个顶点选中的入边
+	cycles [][]int // 这一层被收缩的环
+}
+
+// 从 root 出发有不可达的顶点时返回错误
+func NewArborescence(dig WeightedDigraph, root int) (Arborescence, error) {
+	n := dig.V()
+	unweighted := NewDigraph(n)
+	edges := make([]arbEdge, 0, dig.E())
+	for i, e := range dig.Edges() {
+		unweighted.AddEdge(e.From, e.To)
+		edges = append(edges, arbEdge{e.From, e.To, e.Weight, i})
+	}
+	search := new(DirectedSearchDFS).GenSearch(unweighted, root)
+	for v := 0; v < n; v++ {
+		if !search.Marked(v) {
+			return nil, fmt.Errorf("vertex %d is unreachable from root %d", v, root)
+		}
+	}
+	levels := make([]*arbLevel, 0)
+	r := root
+	for {
+		l := &arbLevel{edges: edges, in: make([]int, n)}
+		for v := range l.in {
+			l.in[v] = -1
+		}
+		for i, e := range edges {
+			if e.u != e.v && e.v != r && (l.in[e.v] < 0 || e.w < edges[l.in[e.v]].w) {
+				l.in[e.v] = i
+			}
+		}
+		// 沿选中的入边往回走, 走回这一轮走过的顶点就找到了一个环
+		comp, mark := make([]int, n), make([]int, n)
+		for v := range comp {
+			comp[v], mark[v] = -1, -1
+		}
+		next := 0
+		for v := 0; v < n; v++ {
+			x := v
+			for x != r && mark[x] < 0 {
+				mark[x] = v
+				x = edges[l.in[x]].u
+			}
+			if x == r || mark[x] != v || comp[x] >= 0 {
+				continue
+			}
+			cycle := make([]int, 0)
+			for y := x; comp[y] < 0; y = edges[l.in[y]].u {
+				comp[y] = next
+				cycle = append(cycle, y)
+			}
+			l.cycles = append(l.cycles, cycle)
+			next++
+		}
+		levels = append(levels, l)
+		if len(l.cycles) == 0 {
+			break
+		}
+		for v := range comp {
+			if comp[v] < 0 {
+				comp[v] = next
+				next++
+			}
+		}
+		contracted := make([]arbEdge, 0, len(edges))
+		for i, e := range edges {
+			a, b := comp[e.u], comp[e.v]
+			if a == b {
+				continue
+			}
+			w := e.w
+			if l.in[e.v] >= 0 && b < len(l.cycles) {
+				w -= edges[l.in[e.v]].w
+			}
+			contracted = append(contracted, arbEdge{a, b, w, i})
+		}
+		n, r, edges = next, comp[r], contracted
+	}
+	// 从最后一层开始逐层展开, chosen 是第 k 层中选中的边的编号
+	k := len(levels) - 1
+	chosen := make([]int, 0)
+	for v, i := range levels[k].in {
+		if v != r {
+			chosen = append(chosen, i)
+		}
+	}
+	for ; k > 0; k-- {
+		lower := levels[k-1]
+		entered := make([]bool, len(lower.in))
+		for j, i := range chosen {
+			chosen[j] = levels[k].edges[i].prev
+			entered[lower.edges[chosen[j]].v] = true
+		}
+		// 每个环恰好有一个顶点被新选中的边指向, 其余顶点保留原来的入边
+		for _, cycle := range lower.cycles {
+			for _, y := range cycle {
+				if !entered[y] {
+					chosen = append(chosen, lower.in[y])
+				}
+			}
+		}
+	}
+	all := dig.Edges()
+	a := &ArborescenceImpl{root: root, parent: make([]int, dig.V())}
+	for v := range a.parent {
+		a.parent[v] = -1
+	}
+	for _, i := range chosen {
+		e := all[levels[0].edges[i].prev]
+		a.parent[e.To] = e.From
+		a.edges = append(a.edges, e)
+		a.weight += e.Weight
+	}
+	sort.Slice(a.edges, func(i, j int) bool { return a.edges[i].To < a.edges[j].To })
+	return a, nil
+}
+
+func (self *ArborescenceImpl) Root() int {
+	return self.root
+}
+
+func (self *ArborescenceImpl) Parent(v int) int {
+	return self.parent[v]
+}
+
+func (self *ArborescenceImpl) Edges() []DirectedEdge {
+	return self.edges
+}
+
+func (self *ArborescenceImpl) Weight() float64 {
+	return self.weight
+}
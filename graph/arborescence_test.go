@@ -0,0 +1,105 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// 枚举每个非根顶点的入边, 所有顶点沿入边都能走到根时是树形图
+func bruteArborescence(dig WeightedDigraph, root int) float64 {
+	n := dig.V()
+	in := make([][]DirectedEdge, n)
+	for _, e := range dig.Edges() {
+		if e.To != root && e.From != e.To {
+			in[e.To] = append(in[e.To], e)
+		}
+	}
+	best := math.Inf(1)
+	parent := make([]int, n)
+	var choose func(v int, weight float64)
+	choose = func(v int, weight float64) {
+		if v == n {
+			for x := 0; x < n; x++ {
+				y := x
+				for i := 0; i < n && y != root; i++ {
+					y = parent[y]
+				}
+				if y != root {
+					return
+				}
+			}
+			best = math.Min(best, weight)
+			return
+		}
+		if v == root {
+			choose(v+1, weight)
+			return
+		}
+		for _, e := range in[v] {
+			parent[v] = e.From
+			choose(v+1, weight+e.Weight)
+		}
+	}
+	choose(0, 0)
+	return best
+}
+
+func assertArborescence(t *testing.T, dig WeightedDigraph, a Arborescence) {
+	n := dig.V()
+	assert.Equal(t, n-1, len(a.Edges()))
+	assert.Equal(t, -1, a.Parent(a.Root()))
+	weight := 0.0
+	for _, e := range a.Edges() {
+		assert.Equal(t, e.From, a.Parent(e.To))
+		weight += e.Weight
+	}
+	assert.InDelta(t, weight, a.Weight(), 1e-9)
+	for v := 0; v < n; v++ {
+		x := v
+		for i := 0; i < n && x != a.Root(); i++ {
+			x = a.Parent(x)
+		}
+		assert.Equal(t, a.Root(), x, "vertex %d", v)
+	}
+}
+
+func TestArborescence(t *testing.T) {
+	// 0->1 (10), 0->2 (1), 1->2 (1), 2->1 (1), 2->3 (5), 1->3 (1)
+	// 最便宜的入边中 1 和 2 组成环, 收缩后从 0 进入环的最小代价是 0->2
+	g := NewEdgeWeightedDigraph(4)
+	for _, e := range []DirectedEdge{{0, 1, 10}, {0, 2, 1}, {1, 2, 1}, {2, 1, 1}, {2, 3, 5}, {1, 3, 1}} {
+		g.AddEdge(e)
+	}
+	a, err := NewArborescence(g, 0)
+	assert.Nil(t, err)
+	assertArborescence(t, g, a)
+	assert.Equal(t, 3.0, a.Weight())
+	assert.Equal(t, []DirectedEdge{{2, 1, 1}, {0, 2, 1}, {1, 3, 1}}, a.Edges())
+
+	_, err = NewArborescence(g, 3)
+	assert.NotNil(t, err)
+	t.Log(err)
+}
+
+func TestArborescenceRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		n := 2 + r.Intn(5)
+		g := NewEdgeWeightedDigraph(n)
+		for j := 0; j < 3*n; j++ {
+			g.AddEdge(DirectedEdge{r.Intn(n), r.Intn(n), float64(r.Intn(20) - 5)})
+		}
+		root := r.Intn(n)
+		want := bruteArborescence(g, root)
+		a, err := NewArborescence(g, root)
+		if math.IsInf(want, 1) {
+			assert.NotNil(t, err)
+			continue
+		}
+		assert.Nil(t, err)
+		assertArborescence(t, g, a)
+		assert.InDelta(t, want, a.Weight(), 1e-9)
+	}
+}
@@ -0,0 +1,130 @@
+package graph
+
+// Edmonds 带花树算法求一般图的最大匹配
+// 从每个未匹配的顶点出发广度优先搜索交错路, 找到奇环 (花) 时把它缩成花根 base,
+// 找到另一个未匹配的顶点时沿 p 和 match 交替翻转路径上的边, 时间复杂度 O(V^3)
+type blossomMatching struct {
+	adj     [][]int
+	match   []int
+	p       []int // 交错树中偶数层顶点到奇数层顶点的父指针
+	base    []int // 顶点所在的花的花根
+	used    []bool
+	blossom []bool
+	queue   []int
+}
+
+func NewBlossomMatching(graph SimpleGraph) Matching {
+	n := graph.V()
+	b := &blossomMatching{
+		adj: loopFreeAdjacency(graph), match: make([]int, n), p: make([]int, n), base: make([]int, n),
+		used: make([]bool, n), blossom: make([]bool, n), queue: make([]int, 0, n),
+	}
+	for v := range b.match {
+		b.match[v] = -1
+	}
+	// 先贪心匹配, 减少需要搜索增广路的次数
+	for v := range b.adj {
+		if b.match[v] >= 0 {
+			continue
+		}
+		for _, w := range b.adj[v] {
+			if b.match[w] < 0 {
+				b.match[v], b.match[w] = w, v
+				break
+			}
+		}
+	}
+	for v := range b.adj {
+		if b.match[v] >= 0 {
+			continue
+		}
+		// 沿增广路翻转
+		for u := b.findPath(v); u >= 0; {
+			pv := b.p[u]
+			ppv := b.match[pv]
+			b.match[u], b.match[pv] = pv, u
+			u = ppv
+		}
+	}
+	m := newMatching(n)
+	for v, w := range b.match {
+		if v < w {
+			m.match(v, w, 1)
+		}
+	}
+	return m
+}
+
+// 沿交错树往上找 a 和 b 最近的公共花根
+func (self *blossomMatching) lca(a, b int) int {
+	seen := make([]bool, len(self.adj))
+	for {
+		a = self.base[a]
+		seen[a] = true
+		if self.match[a] < 0 {
+			break
+		}
+		a = self.p[self.match[a]]
+	}
+	for {
+		b = self.base[b]
+		if seen[b] {
+			return b
+		}
+		b = self.p[self.match[b]]
+	}
+}
+
+// 把 v 到花根 b 路径上的花都标记出来, 并让奇数层顶点的 p 反向指回去, 这样花里的每个顶点都能走到花根
+func (self *blossomMatching) markPath(v, b, child int) {
+	for self.base[v] != b {
+		self.blossom[self.base[v]] = true
+		self.blossom[self.base[self.match[v]]] = true
+		self.p[v] = child
+		child = self.match[v]
+		v = self.p[self.match[v]]
+	}
+}
+
+// 从未匹配的 root 出发找增广路, 返回增广路另一端的未匹配顶点, 找不到时返回 -1
+func (self *blossomMatching) findPath(root int) int {
+	for v := range self.adj {
+		self.used[v], self.p[v], self.base[v] = false, -1, v
+	}
+	self.used[root] = true
+	self.queue = append(self.queue[:0], root)
+	for i := 0; i < len(self.queue); i++ {
+		v := self.queue[i]
+		for _, to := range self.adj[v] {
+			if self.base[v] == self.base[to] || self.match[v] == to {
+				continue
+			}
+			if to == root || self.match[to] >= 0 && self.p[self.match[to]] >= 0 {
+				// v 和 to 都在偶数层, 找到了奇环, 缩成一朵花
+				b := self.lca(v, to)
+				for x := range self.blossom {
+					self.blossom[x] = false
+				}
+				self.markPath(v, b, to)
+				self.markPath(to, b, v)
+				for x := range self.adj {
+					if self.blossom[self.base[x]] {
+						self.base[x] = b
+						if !self.used[x] {
+							self.used[x] = true
+							self.queue = append(self.queue, x)
+						}
+					}
+				}
+			} else if self.p[to] < 0 {
+				self.p[to] = v
+				if self.match[to] < 0 {
+					return to
+				}
+				self.used[self.match[to]] = true
+				self.queue = append(self.queue, self.match[to])
+			}
+		}
+	}
+	return -1
+}
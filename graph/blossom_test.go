@@ -0,0 +1,94 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+// 检查 m 是 graph 中的合法匹配
+func assertGraphMatching(t *testing.T, graph SimpleGraph, m Matching) {
+	size := 0
+	for v := 0; v < graph.V(); v++ {
+		w := m.Mate(v)
+		if w < 0 {
+			continue
+		}
+		assert.Equal(t, v, m.Mate(w))
+		assert.True(t, contains(graph.Adj(v), w), "%d-%d is not an edge", v, w)
+		if v < w {
+			size++
+		}
+	}
+	assert.Equal(t, size, m.Size())
+}
+
+// 位运算动态规划求最大匹配的大小: 最小的未处理顶点要么不匹配, 要么和某个邻居匹配
+func bruteMatchingSize(graph SimpleGraph) int {
+	n := graph.V()
+	adj := loopFreeAdjacency(graph)
+	memo := make(map[int]int)
+	var f func(mask int) int
+	f = func(mask int) int {
+		if mask == 0 {
+			return 0
+		}
+		if r, ok := memo[mask]; ok {
+			return r
+		}
+		v := 0
+		for mask>>uint(v)&1 == 0 {
+			v++
+		}
+		rest := mask &^ (1 << uint(v))
+		best := f(rest)
+		for _, w := range adj[v] {
+			if rest>>uint(w)&1 == 1 {
+				if r := 1 + f(rest&^(1<<uint(w))); r > best {
+					best = r
+				}
+			}
+		}
+		memo[mask] = best
+		return best
+	}
+	return f(1<<uint(n) - 1)
+}
+
+func TestBlossomMatching(t *testing.T) {
+	// 两个三角形用一条边相连, 贪心从 0-1 开始也必须通过花找到增广路
+	// 0-1, 1-2, 2-0, 2-3, 3-4, 4-5, 5-3
+	g := NewGraph(6)
+	for _, e := range [][2]int{{0, 1}, {1, 2}, {2, 0}, {2, 3}, {3, 4}, {4, 5}, {5, 3}} {
+		g.AddEdge(e[0], e[1])
+	}
+	m := NewBlossomMatching(g)
+	assertGraphMatching(t, g, m)
+	assert.Equal(t, 3, m.Size())
+	assert.Equal(t, 3.0, m.Weight())
+
+	// Petersen 图有完美匹配
+	p := NewGraph(10)
+	for i := 0; i < 5; i++ {
+		p.AddEdge(i, (i+1)%5)
+		p.AddEdge(i, i+5)
+		p.AddEdge(i+5, (i+2)%5+5)
+	}
+	m = NewBlossomMatching(p)
+	assertGraphMatching(t, p, m)
+	assert.Equal(t, 5, m.Size())
+}
+
+func TestBlossomMatchingRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		n := 1 + r.Intn(14)
+		g := NewGraph(n)
+		for j := r.Intn(2 * n); j > 0; j-- {
+			g.AddEdge(r.Intn(n), r.Intn(n))
+		}
+		m := NewBlossomMatching(g)
+		assertGraphMatching(t, g, m)
+		assert.Equal(t, bruteMatchingSize(g), m.Size())
+	}
+}
@@ -0,0 +1,262 @@
+package graph
+
+import (
+	"math"
+	"sort"
+)
+
+// 中心性指标, 下标为顶点, 值为该顶点的得分
+type Centrality []float64
+
+// 得分最高的 k 个顶点, 得分相同时编号小的在前; k 超出范围时截到 0 或顶点数
+func (self Centrality) Top(k int) []int {
+	if k < 0 {
+		k = 0
+	}
+	r := make([]int, len(self))
+	for v := range r {
+		r[v] = v
+	}
+	sort.SliceStable(r, func(i, j int) bool {
+		return self[r[i]] > self[r[j]]
+	})
+	if k < len(r) {
+		r = r[:k]
+	}
+	return r
+}
+
+// 一条边的得分
+type EdgeScore struct {
+	V, W  int
+	Score float64
+}
+
+func degreeNorm(n int) float64 {
+	if n <= 1 {
+		return 1
+	}
+	return 1 / float64(n-1)
+}
+
+// 度中心性: 度数 / (V-1), 有向图为出度
+func DegreeCentrality(graph SimpleGraph) Centrality {
+	c := make(Centrality, graph.V())
+	s := degreeNorm(graph.V())
+	for v := range c {
+		c[v] = float64(len(graph.Adj(v))) * s
+	}
+	return c
+}
+
+// 有向图入度中心性: 入度 / (V-1)
+func InDegreeCentrality(dig SimpleDigraph) Centrality {
+	c := make(Centrality, dig.V())
+	s := degreeNorm(dig.V())
+	for v := 0; v < dig.V(); v++ {
+		for _, w := range dig.Adj(v) {
+			c[w] += s
+		}
+	}
+	return c
+}
+
+// 有向图出度中心性: 出度 / (V-1)
+func OutDegreeCentrality(dig SimpleDigraph) Centrality {
+	return DegreeCentrality(dig)
+}
+
+// 从 s 出发的广度优先距离, 不可达为 -1, queue 用来复用内存
+func bfsDist(adj [][]int, s int, dist, queue []int) []int {
+	for i := range dist {
+		dist[i] = -1
+	}
+	dist[s] = 0
+	queue = append(queue[:0], s)
+	for i := 0; i < len(queue); i++ {
+		v := queue[i]
+		for _, w := range adj[v] {
+			if dist[w] < 0 {
+				dist[w] = dist[v] + 1
+				queue = append(queue, w)
+			}
+		}
+	}
+	return queue
+}
+
+// 接近中心性: (r-1) / sum(d(v,u)) * (r-1) / (V-1), r 为 v 能到达的顶点数 (含 v)
+// 乘上后一项 (Wasserman-Faust) 使不连通图中的小连通分量得分不会虚高
+// 有向图按 v 出发的距离计算
+func Closeness(graph SimpleGraph) Centrality {
+	n := graph.V()
+	adj := adjacency(graph)
+	c := make(Centrality, n)
+	dist, queue := make([]int, n), make([]int, 0, n)
+	for v := 0; v < n; v++ {
+		queue = bfsDist(adj, v, dist, queue)
+		sum := 0
+		for _, u := range queue {
+			sum += dist[u]
+		}
+		if r := len(queue) - 1; sum > 0 && n > 1 {
+			c[v] = float64(r) / float64(sum) * float64(r) / float64(n-1)
+		}
+	}
+	return c
+}
+
+// 调和中心性: sum(1 / d(v,u)), u != v 且可达
+// 有向图按 v 出发的距离计算
+func Harmonic(graph SimpleGraph) Centrality {
+	n := graph.V()
+	adj := adjacency(graph)
+	c := make(Centrality, n)
+	dist, queue := make([]int, n), make([]int, 0, n)
+	for v := 0; v < n; v++ {
+		queue = bfsDist(adj, v, dist, queue)
+		for _, u := range queue[1:] {
+			c[v] += 1 / float64(dist[u])
+		}
+	}
+	return c
+}
+
+// Brandes 算法, 一次计算顶点介数和边介数
+// 返回的是未归一化的值, 无向图中每对顶点被计算了两次
+func brandes(adj [][]int, edge func(v, w int, c float64)) Centrality {
+	n := len(adj)
+	cb := make(Centrality, n)
+	sigma, delta := make([]float64, n), make([]float64, n)
+	dist := make([]int, n)
+	pred := make([][]int, n)
+	order := make([]int, 0, n)
+	for s := 0; s < n; s++ {
+		for v := 0; v < n; v++ {
+			sigma[v], delta[v], dist[v], pred[v] = 0, 0, -1, pred[v][:0]
+		}
+		sigma[s], dist[s] = 1, 0
+		order = append(order[:0], s)
+		for i := 0; i < len(order); i++ {
+			v := order[i]
+			for _, w := range adj[v] {
+				if dist[w] < 0 {
+					dist[w] = dist[v] + 1
+					order = append(order, w)
+				}
+				if dist[w] == dist[v]+1 {
+					sigma[w] += sigma[v]
+					pred[w] = append(pred[w], v)
+				}
+			}
+		}
+		// 按距离从远到近回溯, 累加依赖值
+		for i := len(order) - 1; i > 0; i-- {
+			w := order[i]
+			for _, v := range pred[w] {
+				c := sigma[v] / sigma[w] * (1 + delta[w])
+				delta[v] += c
+				if edge != nil {
+					edge(v, w, c)
+				}
+			}
+			cb[w] += delta[w]
+		}
+	}
+	return cb
+}
+
+// 顶点介数中心性: 经过 v 的最短路径所占的比例之和 (Brandes)
+// normalized 为 true 时除以顶点对的数量, 结果落在 [0, 1]
+func Betweenness(graph SimpleGraph, normalized bool) Centrality {
+	n := graph.V()
+	cb := brandes(adjacency(graph), nil)
+	scale := 1.0
+	if !isDirected(graph) {
+		scale = 0.5
+	}
+	if normalized && n > 2 {
+		scale = 1 / float64((n-1)*(n-2))
+	}
+	for v := range cb {
+		cb[v] *= scale
+	}
+	return cb
+}
+
+// 边介数中心性: 经过边 v-w 的最短路径所占的比例之和 (Brandes)
+// 无向图的边只返回 V < W 的方向, 结果按 (V, W) 排序
+func EdgeBetweenness(graph SimpleGraph, normalized bool) []EdgeScore {
+	n := graph.V()
+	directed := isDirected(graph)
+	scores := make(map[[2]int]float64)
+	brandes(adjacency(graph), func(v, w int, c float64) {
+		if !directed && v > w {
+			v, w = w, v
+		}
+		scores[[2]int{v, w}] += c
+	})
+	scale := 1.0
+	if !directed {
+		scale = 0.5
+	}
+	if normalized && n > 1 {
+		scale = 1 / float64(n*(n-1))
+	}
+	r := make([]EdgeScore, 0, len(scores))
+	for e, c := range scores {
+		r = append(r, EdgeScore{e[0], e[1], c * scale})
+	}
+	sort.Slice(r, func(i, j int) bool {
+		if r[i].V != r[j].V {
+			return r[i].V < r[j].V
+		}
+		return r[i].W < r[j].W
+	})
+	return r
+}
+
+// PageRank, damping 为阻尼系数 (通常取 0.85)
+// 没有出边的顶点 (dangling) 把自己的得分平均分给所有顶点
+// 两次迭代之间的 L1 差值小于 V*tolerance 时认为收敛, 超过 maxIter 次仍未收敛时第二个返回值为 false
+// 无向图的每条边按两个方向计算
+func PageRank(graph SimpleGraph, damping, tolerance float64, maxIter int) (Centrality, bool) {
+	n := graph.V()
+	if n == 0 {
+		return Centrality{}, true
+	}
+	adj := adjacency(graph)
+	x, next := make(Centrality, n), make(Centrality, n)
+	for v := range x {
+		x[v] = 1 / float64(n)
+	}
+	for iter := 0; iter < maxIter; iter++ {
+		dangling := 0.0
+		for v := range adj {
+			if len(adj[v]) == 0 {
+				dangling += x[v]
+			}
+		}
+		base := (1-damping)/float64(n) + damping*dangling/float64(n)
+		for v := range next {
+			next[v] = base
+		}
+		for v := range adj {
+			if d := len(adj[v]); d > 0 {
+				share := damping * x[v] / float64(d)
+				for _, w := range adj[v] {
+					next[w] += share
+				}
+			}
+		}
+		err := 0.0
+		for v := range x {
+			err += math.Abs(next[v] - x[v])
+		}
+		x, next = next, x
+		if err < float64(n)*tolerance {
+			return x, true
+		}
+	}
+	return x, false
+}
@@ -0,0 +1,112 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func pathGraph(n int) *Graph {
+	g := NewGraph(n)
+	for v := 0; v+1 < n; v++ {
+		g.AddEdge(v, v+1)
+	}
+	return g
+}
+
+func TestDegreeCentrality(t *testing.T) {
+	g := NewGraphByAdjacencyList(data0)
+	assert.Equal(t, Centrality{0.75, 0.5, 0.25, 0.25, 0.25}, DegreeCentrality(g))
+
+	d := NewDigraph(3)
+	d.AddEdge(0, 1)
+	d.AddEdge(0, 2)
+	d.AddEdge(1, 2)
+	assert.Equal(t, Centrality{1, 0.5, 0}, OutDegreeCentrality(d))
+	assert.Equal(t, Centrality{0, 0.5, 1}, InDegreeCentrality(d))
+}
+
+func TestCloseness(t *testing.T) {
+	c := Closeness(pathGraph(5))
+	assert.InDelta(t, 4.0/10, c[0], 1e-9)
+	assert.InDelta(t, 4.0/7, c[1], 1e-9)
+	assert.InDelta(t, 4.0/6, c[2], 1e-9)
+	assert.Equal(t, []int{2, 1, 3}, c.Top(3))
+
+	h := Harmonic(pathGraph(5))
+	assert.InDelta(t, 1+1.0/2+1.0/3+1.0/4, h[0], 1e-9)
+	assert.InDelta(t, 2+1.0, h[2], 1e-9)
+
+	// 两个互不连通的三角形, 得分只在各自的连通分量里计算
+	c = Closeness(NewGraphByData(data1))
+	assert.InDelta(t, 2.0/2*2.0/5, c[0], 1e-9)
+}
+
+func TestBetweenness(t *testing.T) {
+	assert.Equal(t, Centrality{0, 3, 4, 3, 0}, Betweenness(pathGraph(5), false))
+
+	star := NewGraph(6)
+	for v := 1; v < 6; v++ {
+		star.AddEdge(0, v)
+	}
+	b := Betweenness(star, true)
+	assert.InDelta(t, 1, b[0], 1e-9)
+	assert.Equal(t, 0.0, b[1])
+
+	d := NewDigraph(3)
+	d.AddEdge(0, 1)
+	d.AddEdge(1, 2)
+	assert.Equal(t, Centrality{0, 1, 0}, Betweenness(d, false))
+
+	// 4 个顶点的环, 每对对角顶点有两条最短路径
+	cycle := pathGraph(4)
+	cycle.AddEdge(3, 0)
+	assert.Equal(t, Centrality{0.5, 0.5, 0.5, 0.5}, Betweenness(cycle, false))
+}
+
+func TestEdgeBetweenness(t *testing.T) {
+	eb := EdgeBetweenness(pathGraph(4), false)
+	assert.Equal(t, []EdgeScore{{0, 1, 3}, {1, 2, 4}, {2, 3, 3}}, eb)
+
+	d := NewDigraph(3)
+	d.AddEdge(0, 1)
+	d.AddEdge(1, 2)
+	assert.Equal(t, []EdgeScore{{0, 1, 2}, {1, 2, 2}}, EdgeBetweenness(d, false))
+}
+
+func TestPageRank(t *testing.T) {
+	cycle := NewDigraph(4)
+	for v := 0; v < 4; v++ {
+		cycle.AddEdge(v, (v+1)%4)
+	}
+	pr, ok := PageRank(cycle, 0.85, 1e-10, 100)
+	assert.True(t, ok)
+	for _, x := range pr {
+		assert.InDelta(t, 0.25, x, 1e-9)
+	}
+
+	// 3 没有出边, 它的得分平均分给所有顶点, 总和仍为 1
+	d := NewDigraph(4)
+	d.AddEdge(0, 1)
+	d.AddEdge(1, 2)
+	d.AddEdge(2, 0)
+	d.AddEdge(2, 3)
+	pr, ok = PageRank(d, 0.85, 1e-10, 100)
+	assert.True(t, ok)
+	sum := 0.0
+	for _, x := range pr {
+		sum += x
+	}
+	assert.InDelta(t, 1, sum, 1e-9)
+	assert.Equal(t, 2, pr.Top(1)[0])
+
+	_, ok = PageRank(d, 0.85, 1e-15, 2)
+	assert.False(t, ok)
+}
+
+func TestCentralityTop(t *testing.T) {
+	c := Centrality{0.1, 0.5, 0.5, 0.9}
+	assert.Equal(t, []int{3, 1, 2}, c.Top(3))
+	assert.Equal(t, []int{3, 1, 2, 0}, c.Top(10))
+	assert.Empty(t, c.Top(0))
+	assert.Empty(t, c.Top(-1))
+}
@@ -0,0 +1,182 @@
+package graph
+
+import (
+	"sort"
+)
+
+// 枚举无向图中所有的极大团 (Bron–Kerbosch + 选取枢轴 + 退化序)
+// 每找到一个极大团就回调 f, 团中的顶点按编号排序, f 返回 false 时停止枚举
+// minSize 是团的大小下限, 小于它的团不会回调, 并且不可能达到下限的分支会被剪掉
+// maxSize > 0 时是团的大小上限, 搜索到 maxSize 个顶点的团就回调并且不再扩展, 这样的团不一定是极大的,
+// 但每个更大的极大团都至少包含一个回调过的团; 稠密图上用它限制搜索的深度
+// 全部枚举完返回 true, 被 f 终止时返回 false
+// 按退化序处理顶点, 时间复杂度 O(d * V * 3^(d/3)), d 为退化度
+func MaximalCliques(graph SimpleGraph, minSize, maxSize int, f func(clique []int) bool) bool {
+	bk := newBronKerbosch(graph)
+	bk.minSize, bk.maxSize = minSize, maxSize
+	bk.report = func(r []int) bool {
+		clique := append([]int(nil), r...)
+		sort.Ints(clique)
+		return f(clique)
+	}
+	return bk.run()
+}
+
+// 最大团, 即顶点数最多的团
+// 枚举极大团时把下限设为当前最大团的大小加一, 用来剪枝
+func MaximumClique(graph SimpleGraph) []int {
+	var best []int
+	bk := newBronKerbosch(graph)
+	bk.report = func(r []int) bool {
+		best = append([]int(nil), r...)
+		bk.minSize = len(best) + 1
+		return true
+	}
+	bk.run()
+	sort.Ints(best)
+	return best
+}
+
+type bronKerbosch struct {
+	adj     [][]int // 排好序的邻接表, 不含自环
+	order   []int   // 退化序
+	pos     []int   // 顶点在退化序中的位置
+	minSize int
+	maxSize int
+	report  func(r []int) bool
+}
+
+func newBronKerbosch(graph SimpleGraph) *bronKerbosch {
+	n := graph.V()
+	bk := &bronKerbosch{adj: make([][]int, n), pos: make([]int, n)}
+	for v := 0; v < n; v++ {
+		for _, w := range graph.Adj(v) {
+			if w != v {
+				bk.adj[v] = append(bk.adj[v], w)
+			}
+		}
+		sort.Ints(bk.adj[v])
+	}
+	bk.order = NewCores(graph).Order()
+	for i, v := range bk.order {
+		bk.pos[v] = i
+	}
+	return bk
+}
+
+func (self *bronKerbosch) run() bool {
+	for _, v := range self.order {
+		// 退化序中 v 之后的邻居是候选, 之前的邻居已经处理过
+		p, x := make([]int, 0), make([]int, 0)
+		for _, w := range self.adj[v] {
+			if self.pos[w] > self.pos[v] {
+				p = append(p, w)
+			} else {
+				x = append(x, w)
+			}
+		}
+		if !self.extend([]int{v}, p, x) {
+			return false
+		}
+	}
+	return true
+}
+
+// r 是当前的团, p 是可以加入的候选顶点, x 是已经枚举过的顶点, p 和 x 都是有序的
+func (self *bronKerbosch) extend(r, p, x []int) bool {
+	if self.maxSize > 0 && len(r) >= self.maxSize || len(p) == 0 && len(x) == 0 {
+		if len(r) >= self.minSize {
+			return self.report(r)
+		}
+		return true
+	}
+	if len(r)+len(p) < self.minSize {
+		return true
+	}
+	// 选择在 p 中邻居最多的顶点作为枢轴, 只需要展开 p 中不与枢轴相邻的顶点
+	pivot, most := -1, -1
+	for _, s := range [][]int{p, x} {
+		for _, u := range s {
+			if c := countCommon(p, self.adj[u]); c > most {
+				pivot, most = u, c
+			}
+		}
+	}
+	candidates := subtract(p, self.adj[pivot])
+	for _, v := range candidates {
+		nv := self.adj[v]
+		if !self.extend(append(r, v), intersect(p, nv), intersect(x, nv)) {
+			return false
+		}
+		p = remove(p, v)
+		x = insert(x, v)
+		if len(r)+len(p) < self.minSize {
+			return true
+		}
+	}
+	return true
+}
+
+// 以下都是有序切片上的集合运算
+func countCommon(a, b []int) int {
+	n := 0
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			n++
+			i++
+			j++
+		}
+	}
+	return n
+}
+
+func intersect(a, b []int) []int {
+	r := make([]int, 0)
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			r = append(r, a[i])
+			i++
+			j++
+		}
+	}
+	return r
+}
+
+func subtract(a, b []int) []int {
+	r := make([]int, 0)
+	j := 0
+	for _, v := range a {
+		for j < len(b) && b[j] < v {
+			j++
+		}
+		if j == len(b) || b[j] != v {
+			r = append(r, v)
+		}
+	}
+	return r
+}
+
+func remove(a []int, v int) []int {
+	i := sort.SearchInts(a, v)
+	r := make([]int, 0, len(a))
+	r = append(r, a[:i]...)
+	return append(r, a[i+1:]...)
+}
+
+func insert(a []int, v int) []int {
+	i := sort.SearchInts(a, v)
+	r := make([]int, 0, len(a)+1)
+	r = append(r, a[:i]...)
+	r = append(r, v)
+	return append(r, a[i:]...)
+}
@@ -0,0 +1,141 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestMaximalCliques(t *testing.T) {
+	// data2 的极大团: {0,1,5} {1,2,3} {1,3,5} {3,4,5}
+	g := NewGraphByAdjacencyList(data2)
+	cliques := [][]int{}
+	assert.True(t, MaximalCliques(g, 0, 0, func(c []int) bool {
+		cliques = append(cliques, c)
+		return true
+	}))
+	assert.ElementsMatch(t, [][]int{{0, 1, 5}, {1, 2, 3}, {1, 3, 5}, {3, 4, 5}}, cliques)
+
+	// 在第二个团处停止
+	n := 0
+	assert.False(t, MaximalCliques(g, 0, 0, func(c []int) bool {
+		n++
+		return n < 2
+	}))
+	assert.Equal(t, 2, n)
+
+	// 下限为 4 时什么也找不到
+	MaximalCliques(g, 4, 0, func(c []int) bool {
+		t.Errorf("unexpected clique %v", c)
+		return true
+	})
+}
+
+func cliqueKey(c []int) string {
+	key := ""
+	for _, v := range c {
+		key += string(rune(v + 'A'))
+	}
+	return key
+}
+
+func TestMaximalCliquesRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	g := NewGraph(40)
+	for i := 0; i < 300; i++ {
+		g.AddEdge(r.Intn(40), r.Intn(40))
+	}
+	has := make(map[[2]int]bool)
+	for v := 0; v < 40; v++ {
+		for _, w := range g.Adj(v) {
+			has[[2]int{v, w}] = true
+		}
+	}
+	isClique := func(c []int) bool {
+		for i := range c {
+			for j := i + 1; j < len(c); j++ {
+				if !has[[2]int{c[i], c[j]}] {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	seen := make(map[string]bool)
+	largest := 0
+	MaximalCliques(g, 0, 0, func(c []int) bool {
+		assert.True(t, isClique(c))
+		// 极大: 不存在可以加入的顶点
+		for v := 0; v < 40; v++ {
+			if !contains(c, v) {
+				assert.False(t, isClique(append(append([]int{}, c...), v)), "clique %v is not maximal", c)
+			}
+		}
+		key := cliqueKey(c)
+		assert.False(t, seen[key])
+		seen[key] = true
+		if len(c) > largest {
+			largest = len(c)
+		}
+		return true
+	})
+	best := MaximumClique(g)
+	assert.True(t, isClique(best))
+	assert.Equal(t, largest, len(best))
+
+	// 上限为 3: 小的团仍然是极大的, 每个更大的极大团都包含一个回调过的 3 顶点团
+	capped := make([][]int, 0)
+	MaximalCliques(g, 0, 3, func(c []int) bool {
+		assert.True(t, isClique(c))
+		assert.True(t, len(c) <= 3)
+		capped = append(capped, c)
+		return true
+	})
+	for _, c := range capped {
+		if len(c) < 3 {
+			assert.True(t, seen[cliqueKey(c)], "clique %v is not maximal", c)
+		}
+	}
+	MaximalCliques(g, 4, 0, func(c []int) bool {
+		covered := false
+		for _, s := range capped {
+			if len(s) == 3 && contains(c, s[0]) && contains(c, s[1]) && contains(c, s[2]) {
+				covered = true
+			}
+		}
+		assert.True(t, covered, "clique %v is not covered", c)
+		return true
+	})
+}
+
+func TestMaximalCliquesMaxSize(t *testing.T) {
+	// 完全图只有一个极大团, 上限让搜索在 3 个顶点处停下
+	g := NewGraph(30)
+	for v := 0; v < 30; v++ {
+		for w := v + 1; w < 30; w++ {
+			g.AddEdge(v, w)
+		}
+	}
+	cliques := [][]int{}
+	MaximalCliques(g, 0, 3, func(c []int) bool {
+		cliques = append(cliques, c)
+		return true
+	})
+	assert.Equal(t, 1, len(cliques))
+	assert.Equal(t, 3, len(cliques[0]))
+
+	// 下限比上限大时什么也找不到
+	assert.True(t, MaximalCliques(g, 4, 3, func(c []int) bool {
+		t.Errorf("unexpected clique %v", c)
+		return true
+	}))
+}
+
+func contains(s []int, v int) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,267 @@
+package graph
+
+import (
+	"container/heap"
+	"math/rand"
+	"sort"
+)
+
+// 着色的结果
+type ColoringImpl struct {
+	color []int
+	count int
+}
+
+func newColoring(color []int) *ColoringImpl {
+	c := &ColoringImpl{color: color}
+	for _, x := range color {
+		if x+1 > c.count {
+			c.count = x + 1
+		}
+	}
+	return c
+}
+
+func (self *ColoringImpl) Color(v int) int {
+	return self.color[v]
+}
+
+func (self *ColoringImpl) Count() int {
+	return self.count
+}
+
+func (self *ColoringImpl) Classes() [][]int {
+	r := make([][]int, self.count)
+	for v, c := range self.color {
+		r[c] = append(r[c], v)
+	}
+	return r
+}
+
+// 去掉自环的邻接表, 自环的顶点无法正常着色, 着色时忽略自环
+func loopFreeAdjacency(graph SimpleGraph) [][]int {
+	adj := make([][]int, graph.V())
+	for v := range adj {
+		for _, w := range graph.Adj(v) {
+			if w != v {
+				adj[v] = append(adj[v], w)
+			}
+		}
+	}
+	return adj
+}
+
+// 贪心着色: 按 order 的顺序给每个顶点分配邻居没有用过的最小颜色
+// order 为 nil 时按顶点编号的顺序, 也可以用 LargestFirstOrder 等函数生成
+// order 中没有列出的顶点随后按编号着色, 重复出现的顶点只在第一次着色
+func NewGreedyColoring(graph SimpleGraph, order []int) Coloring {
+	n := graph.V()
+	adj := loopFreeAdjacency(graph)
+	color := make([]int, n)
+	for v := range color {
+		color[v] = -1
+	}
+	all := make([]int, 0, n)
+	for _, v := range order {
+		if v < 0 || v >= n {
+			panic("error number")
+		}
+		all = append(all, v)
+	}
+	for v := 0; v < n; v++ {
+		all = append(all, v)
+	}
+	used := make([]int, n+1) // used[c] == v+1 表示 v 的邻居用过颜色 c
+	for _, v := range all {
+		if color[v] >= 0 {
+			continue
+		}
+		for _, w := range adj[v] {
+			if color[w] >= 0 {
+				used[color[w]] = v + 1
+			}
+		}
+		c := 0
+		for used[c] == v+1 {
+			c++
+		}
+		color[v] = c
+	}
+	return newColoring(color)
+}
+
+// 按度数从大到小排列顶点 (Welsh–Powell)
+func LargestFirstOrder(graph SimpleGraph) []int {
+	adj := loopFreeAdjacency(graph)
+	order := make([]int, len(adj))
+	for v := range order {
+		order[v] = v
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(adj[order[i]]) > len(adj[order[j]])
+	})
+	return order
+}
+
+// 退化序倒过来 (smallest last), 贪心着色最多使用 退化度+1 种颜色
+func SmallestLastOrder(graph SimpleGraph) []int {
+	order := append([]int(nil), NewCores(graph).Order()...)
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return order
+}
+
+// 随机顺序, 相同的 seed 得到相同的顺序
+func RandomOrder(graph SimpleGraph, seed int64) []int {
+	return rand.New(rand.NewSource(seed)).Perm(graph.V())
+}
+
+// DSatur 堆中的元素, 饱和度高的优先, 相同时度数高的优先
+type dsaturItem struct {
+	v, sat, degree int
+}
+
+type dsaturHeap []dsaturItem
+
+func (self dsaturHeap) Len() int { return len(self) }
+func (self dsaturHeap) Less(i, j int) bool {
+	if self[i].sat != self[j].sat {
+		return self[i].sat > self[j].sat
+	}
+	if self[i].degree != self[j].degree {
+		return self[i].degree > self[j].degree
+	}
+	return self[i].v < self[j].v
+}
+func (self dsaturHeap) Swap(i, j int)       { self[i], self[j] = self[j], self[i] }
+func (self *dsaturHeap) Push(x interface{}) { *self = append(*self, x.(dsaturItem)) }
+func (self *dsaturHeap) Pop() interface{} {
+	old := *self
+	x := old[len(old)-1]
+	*self = old[:len(old)-1]
+	return x
+}
+
+// DSatur 启发式着色: 每次选择饱和度 (邻居用到的不同颜色数) 最高的顶点,
+// 分配邻居没有用过的最小颜色; 饱和度变化时向堆中压入新的元素, 取出时跳过过期的元素
+func NewDSaturColoring(graph SimpleGraph) Coloring {
+	n := graph.V()
+	adj := loopFreeAdjacency(graph)
+	color := make([]int, n)
+	neighbor := make([]map[int]bool, n) // 邻居用到的颜色
+	h := make(dsaturHeap, 0, n)
+	for v := range color {
+		color[v] = -1
+		neighbor[v] = make(map[int]bool)
+		h = append(h, dsaturItem{v, 0, len(adj[v])})
+	}
+	heap.Init(&h)
+	for h.Len() > 0 {
+		it := heap.Pop(&h).(dsaturItem)
+		v := it.v
+		if color[v] >= 0 || it.sat != len(neighbor[v]) {
+			continue
+		}
+		c := 0
+		for neighbor[v][c] {
+			c++
+		}
+		color[v] = c
+		for _, w := range adj[v] {
+			if color[w] < 0 && !neighbor[w][c] {
+				neighbor[w][c] = true
+				heap.Push(&h, dsaturItem{w, len(neighbor[w]), len(adj[w])})
+			}
+		}
+	}
+	return newColoring(color)
+}
+
+// 精确着色, 返回的着色使用的颜色数就是色数
+// 回溯搜索: 按 DSatur 规则选择下一个顶点, 依次尝试已用的颜色和一种新颜色,
+// 用 DSatur 的结果作为上界, 最大团的大小作为下界剪枝
+// 最坏情况是指数时间, 只适合顶点数不多 (几十个) 的图
+func NewExactColoring(graph SimpleGraph) Coloring {
+	n := graph.V()
+	best := NewDSaturColoring(graph).(*ColoringImpl)
+	lower := len(MaximumClique(graph))
+	if best.Count() <= lower || n == 0 {
+		return best
+	}
+	ec := &exactColoring{
+		adj:   loopFreeAdjacency(graph),
+		color: make([]int, n),
+		cnt:   make([][]int, n),
+		sat:   make([]int, n),
+		best:  append([]int(nil), best.color...),
+		upper: best.Count(),
+		lower: lower,
+	}
+	for v := range ec.color {
+		ec.color[v] = -1
+		ec.cnt[v] = make([]int, ec.upper)
+	}
+	ec.search(0, 0)
+	return newColoring(ec.best)
+}
+
+type exactColoring struct {
+	adj          [][]int
+	color        []int
+	cnt          [][]int // cnt[v][c] 为 v 的颜色为 c 的邻居数
+	sat          []int
+	best         []int
+	upper, lower int // 当前最优解的颜色数, 以及色数的下界
+}
+
+// colored 为已经着色的顶点数, used 为已经用到的颜色数
+// 找到颜色数等于下界的解时返回 true, 提前结束搜索
+func (self *exactColoring) search(colored, used int) bool {
+	if colored == len(self.color) {
+		self.upper = used
+		copy(self.best, self.color)
+		return used <= self.lower
+	}
+	v := -1
+	for u, c := range self.color {
+		if c < 0 && (v < 0 || self.sat[u] > self.sat[v] ||
+			self.sat[u] == self.sat[v] && len(self.adj[u]) > len(self.adj[v])) {
+			v = u
+		}
+	}
+	for c := 0; c <= used && c < self.upper-1; c++ {
+		if self.cnt[v][c] > 0 {
+			continue
+		}
+		self.paint(v, c, 1)
+		next := used
+		if c == used {
+			next++
+		}
+		done := self.search(colored+1, next)
+		self.paint(v, c, -1)
+		if done {
+			return true
+		}
+	}
+	return false
+}
+
+// 给 v 着色 (d 为 1) 或撤销着色 (d 为 -1), 同时维护邻居的饱和度
+func (self *exactColoring) paint(v, c, d int) {
+	if d > 0 {
+		self.color[v] = c
+	} else {
+		self.color[v] = -1
+	}
+	for _, w := range self.adj[v] {
+		if d > 0 && self.cnt[w][c] == 0 {
+			self.sat[w]++
+		}
+		self.cnt[w][c] += d
+		if d < 0 && self.cnt[w][c] == 0 {
+			self.sat[w]--
+		}
+	}
+}
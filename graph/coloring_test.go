@@ -0,0 +1,126 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func assertProperColoring(t *testing.T, g SimpleGraph, c Coloring) {
+	total := 0
+	for color, class := range c.Classes() {
+		total += len(class)
+		for _, v := range class {
+			assert.Equal(t, color, c.Color(v))
+		}
+	}
+	assert.Equal(t, g.V(), total)
+	for v := 0; v < g.V(); v++ {
+		assert.True(t, c.Color(v) >= 0 && c.Color(v) < c.Count())
+		for _, w := range g.Adj(v) {
+			if w != v {
+				assert.NotEqual(t, c.Color(v), c.Color(w), "edge %d-%d", v, w)
+			}
+		}
+	}
+}
+
+// Grötzsch 图: 11 个顶点, 不含三角形, 色数为 4
+func grotzsch() *Graph {
+	g := NewGraph(11)
+	for v := 0; v < 5; v++ {
+		g.AddEdge(v, (v+1)%5)
+		g.AddEdge(v+5, (v+4)%5)
+		g.AddEdge(v+5, (v+1)%5)
+		g.AddEdge(v+5, 10)
+	}
+	return g
+}
+
+func TestGreedyColoring(t *testing.T) {
+	g := NewGraphByAdjacencyList(data2)
+	for _, order := range [][]int{nil, LargestFirstOrder(g), SmallestLastOrder(g), RandomOrder(g, 1)} {
+		assertProperColoring(t, g, NewGreedyColoring(g, order))
+	}
+	// 不完整和重复的顺序: 没有列出的顶点随后着色
+	partial := NewGreedyColoring(g, []int{5, 3, 3})
+	assertProperColoring(t, g, partial)
+	assert.Equal(t, 0, partial.Color(5))
+	assert.Equal(t, 1, partial.Color(3))
+	assert.Panics(t, func() { NewGreedyColoring(g, []int{g.V()}) })
+	r := rand.New(rand.NewSource(1))
+	big := NewGraph(500)
+	for i := 0; i < 5000; i++ {
+		big.AddEdge(r.Intn(500), r.Intn(500))
+	}
+	c := NewGreedyColoring(big, SmallestLastOrder(big))
+	assertProperColoring(t, big, c)
+	assert.True(t, c.Count() <= NewCores(big).Degeneracy()+1)
+	assertProperColoring(t, big, NewDSaturColoring(big))
+}
+
+func TestDSaturColoring(t *testing.T) {
+	// DSatur 对二分图总是得到 2 种颜色
+	g := NewGraphByAdjacencyList(data3)
+	c := NewDSaturColoring(g)
+	assertProperColoring(t, g, c)
+	assert.Equal(t, 2, c.Count())
+	assert.Equal(t, 1, NewDSaturColoring(NewGraph(3)).Count())
+}
+
+func TestExactColoring(t *testing.T) {
+	cycle := func(n int) *Graph {
+		g := pathGraph(n)
+		g.AddEdge(n-1, 0)
+		return g
+	}
+	assert.Equal(t, 2, NewExactColoring(cycle(6)).Count())
+	assert.Equal(t, 3, NewExactColoring(cycle(7)).Count())
+	c := NewExactColoring(grotzsch())
+	assertProperColoring(t, grotzsch(), c)
+	assert.Equal(t, 4, c.Count())
+
+	// 与暴力枚举对照
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 30; i++ {
+		g := NewGraph(9)
+		for j := 0; j < 18; j++ {
+			g.AddEdge(r.Intn(9), r.Intn(9))
+		}
+		c := NewExactColoring(g)
+		assertProperColoring(t, g, c)
+		assert.Equal(t, bruteChromatic(g), c.Count())
+	}
+}
+
+// 枚举所有着色方案求色数
+func bruteChromatic(g SimpleGraph) int {
+	n := g.V()
+	color := make([]int, n)
+	for k := 1; ; k++ {
+		var try func(v int) bool
+		try = func(v int) bool {
+			if v == n {
+				return true
+			}
+			for c := 0; c < k; c++ {
+				ok := true
+				for _, w := range g.Adj(v) {
+					if w < v && color[w] == c {
+						ok = false
+					}
+				}
+				if ok {
+					color[v] = c
+					if try(v + 1) {
+						return true
+					}
+				}
+			}
+			return false
+		}
+		if try(0) {
+			return k
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package graph
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// 社区划分的结果
+type CommunitiesImpl struct {
+	id         []int
+	count      int
+	modularity float64
+}
+
+func (self *CommunitiesImpl) Connected(v, w int) bool {
+	return self.id[v] == self.id[w]
+}
+
+func (self *CommunitiesImpl) Count() int {
+	return self.count
+}
+
+func (self *CommunitiesImpl) ID(v int) int {
+	return self.id[v]
+}
+
+func (self *CommunitiesImpl) Modularity() float64 {
+	return self.modularity
+}
+
+// 把任意的标签重新编号为 0..count-1, 按顶点编号第一次出现的顺序
+func newCommunities(graph WeightedGraph, label []int) *CommunitiesImpl {
+	c := &CommunitiesImpl{id: make([]int, len(label))}
+	ids := make(map[int]int)
+	for v, l := range label {
+		id, ok := ids[l]
+		if !ok {
+			id = c.count
+			ids[l] = id
+			c.count++
+		}
+		c.id[v] = id
+	}
+	c.modularity = Modularity(graph, c.id)
+	return c
+}
+
+// 划分 id 的模块度 Q = sum_c ( L_c / m - (D_c / 2m)^2 )
+// m 为总权重, L_c 为社区 c 内部的边权重之和, D_c 为社区 c 中顶点的加权度数之和
+func Modularity(graph WeightedGraph, id []int) float64 {
+	in := make(map[int]float64)
+	deg := make(map[int]float64)
+	m := 0.0
+	for _, e := range graph.Edges() {
+		m += e.Weight
+		deg[id[e.V]] += e.Weight
+		deg[id[e.W]] += e.Weight
+		if id[e.V] == id[e.W] {
+			in[id[e.V]] += e.Weight
+		}
+	}
+	if m == 0 {
+		return 0
+	}
+	// 按社区编号的顺序求和, 同一个划分每次得到完全相同的值
+	cs := make([]int, 0, len(deg))
+	for c := range deg {
+		cs = append(cs, c)
+	}
+	sort.Ints(cs)
+	q := 0.0
+	for _, c := range cs {
+		d := deg[c]
+		q += in[c]/m - (d/(2*m))*(d/(2*m))
+	}
+	return q
+}
+
+// 标签传播 Label Propagation
+// 每个顶点反复采用邻居中权重之和最大的标签, 直到所有顶点的标签都是邻居中的多数标签
+// 顶点的处理顺序和平局都由 seed 决定
+func NewLabelPropagation(graph SimpleGraph, seed int64) Communities {
+	return NewWeightedLabelPropagation(NewEdgeWeightedGraphByGraph(graph), seed)
+}
+
+// 加权图的标签传播
+func NewWeightedLabelPropagation(graph WeightedGraph, seed int64) Communities {
+	r := rand.New(rand.NewSource(seed))
+	n := graph.V()
+	label := make([]int, n)
+	for v := range label {
+		label[v] = v
+	}
+	weight := make(map[int]float64)
+	best := make([]int, 0)
+	// 计算 v 邻居中权重最大的标签集合
+	majority := func(v int) []int {
+		for l := range weight {
+			delete(weight, l)
+		}
+		for _, e := range graph.Adj(v) {
+			if w := e.Other(v); w != v {
+				weight[label[w]] += e.Weight
+			}
+		}
+		best = best[:0]
+		max := 0.0
+		for l, w := range weight {
+			if len(best) == 0 || w > max {
+				best, max = append(best[:0], l), w
+			} else if w == max {
+				best = append(best, l)
+			}
+		}
+		return best
+	}
+	const maxRounds = 100
+	for round := 0; round < maxRounds; round++ {
+		changed := false
+		for _, v := range r.Perm(n) {
+			best := majority(v)
+			if len(best) == 0 || hasLabel(best, label[v]) {
+				continue
+			}
+			// map 的遍历顺序是随机的, 先排序再用 seed 选取, 保证结果可复现
+			sort.Ints(best)
+			label[v] = best[r.Intn(len(best))]
+			changed = true
+		}
+		if !changed {
+			break
+		}
+	}
+	return newCommunities(graph, label)
+}
+
+func hasLabel(labels []int, l int) bool {
+	for _, x := range labels {
+		if x == l {
+			return true
+		}
+	}
+	return false
+}
+
+// Louvain 模块度优化
+// 第一阶段把每个顶点移动到模块度增益最大的相邻社区, 直到不再有顶点移动;
+// 第二阶段把每个社区缩成一个顶点, 在缩小的图上重复, 直到模块度不再提高
+// 顶点的处理顺序由 seed 决定, 相同的 seed 得到相同的划分
+func NewLouvain(graph SimpleGraph, seed int64) Communities {
+	return NewWeightedLouvain(NewEdgeWeightedGraphByGraph(graph), seed)
+}
+
+// 加权图的 Louvain 模块度优化
+func NewWeightedLouvain(graph WeightedGraph, seed int64) Communities {
+	r := rand.New(rand.NewSource(seed))
+	n := graph.V()
+	// 原图顶点所在的社区
+	label := make([]int, n)
+	for v := range label {
+		label[v] = v
+	}
+	lv := newLouvainLevel(graph)
+	for {
+		community, moved := lv.move(r)
+		if !moved {
+			break
+		}
+		for v := range label {
+			label[v] = community[label[v]]
+		}
+		lv = lv.aggregate(community)
+	}
+	return newCommunities(graph, label)
+}
+
+type louvainEdge struct {
+	to     int
+	weight float64
+}
+
+// Louvain 的一层: 顶点是上一层的社区
+type louvainLevel struct {
+	adj  [][]louvainEdge // 不含自环
+	loop []float64       // 自环权重
+	k    []float64       // 加权度数, 自环计算两次
+	m2   float64         // 总权重的两倍
+}
+
+func newLouvainLevel(graph WeightedGraph) *louvainLevel {
+	n := graph.V()
+	lv := &louvainLevel{adj: make([][]louvainEdge, n), loop: make([]float64, n), k: make([]float64, n)}
+	for _, e := range graph.Edges() {
+		lv.add(e.V, e.W, e.Weight)
+	}
+	return lv
+}
+
+func (self *louvainLevel) add(v, w int, weight float64) {
+	if v == w {
+		self.loop[v] += weight
+	} else {
+		self.adj[v] = append(self.adj[v], louvainEdge{w, weight})
+		self.adj[w] = append(self.adj[w], louvainEdge{v, weight})
+	}
+	self.k[v] += weight
+	self.k[w] += weight
+	self.m2 += 2 * weight
+}
+
+// 第一阶段, 返回每个顶点所在的社区 (已重新编号为 0..count-1) 以及是否有顶点移动过
+func (self *louvainLevel) move(r *rand.Rand) ([]int, bool) {
+	n := len(self.adj)
+	community := make([]int, n)
+	tot := make([]float64, n) // 社区中顶点的度数之和
+	for v := range community {
+		community[v] = v
+		tot[v] = self.k[v]
+	}
+	if self.m2 == 0 {
+		return community, false
+	}
+	neigh := make([]float64, n) // v 到各个相邻社区的权重
+	touched := make([]int, 0)
+	moved := false
+	for improved := true; improved; {
+		improved = false
+		for _, v := range r.Perm(n) {
+			c := community[v]
+			touched = touched[:0]
+			for _, e := range self.adj[v] {
+				d := community[e.to]
+				if neigh[d] == 0 {
+					touched = append(touched, d)
+				}
+				neigh[d] += e.weight
+			}
+			tot[c] -= self.k[v]
+			// 增益 = k_v,in(d) - tot(d) * k_v / 2m, 比较时省略公共的系数
+			// 增益相同时留在原来的社区, 否则选编号最小的社区, 结果与邻接表的顺序无关
+			best, bestGain := c, neigh[c]-tot[c]*self.k[v]/self.m2
+			for _, d := range touched {
+				gain := neigh[d] - tot[d]*self.k[v]/self.m2
+				if gain > bestGain+1e-12 || best != c && d < best && gain > bestGain-1e-12 {
+					best, bestGain = d, gain
+				}
+			}
+			tot[best] += self.k[v]
+			if best != c {
+				community[v] = best
+				improved, moved = true, true
+			}
+			for _, d := range touched {
+				neigh[d] = 0
+			}
+			neigh[c] = 0
+		}
+	}
+	// 重新编号
+	ids := make(map[int]int)
+	for v, c := range community {
+		id, ok := ids[c]
+		if !ok {
+			id = len(ids)
+			ids[c] = id
+		}
+		community[v] = id
+	}
+	return community, moved
+}
+
+// 第二阶段, 把同一个社区的顶点缩成一个顶点
+func (self *louvainLevel) aggregate(community []int) *louvainLevel {
+	count := 0
+	for _, c := range community {
+		if c+1 > count {
+			count = c + 1
+		}
+	}
+	lv := &louvainLevel{adj: make([][]louvainEdge, count), loop: make([]float64, count), k: make([]float64, count)}
+	weight := make(map[[2]int]float64)
+	for v := range self.adj {
+		if self.loop[v] != 0 {
+			c := community[v]
+			weight[[2]int{c, c}] += self.loop[v]
+		}
+		for _, e := range self.adj[v] {
+			// 每条边在两端各出现一次, 只取一次
+			if e.to > v {
+				a, b := community[v], community[e.to]
+				if a > b {
+					a, b = b, a
+				}
+				weight[[2]int{a, b}] += e.weight
+			}
+		}
+	}
+	keys := make([][2]int, 0, len(weight))
+	for key := range weight {
+		keys = append(keys, key)
+	}
+	// 按顺序加边, 保证相同的 seed 得到相同的结果
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	for _, key := range keys {
+		lv.add(key[0], key[1], weight[key])
+	}
+	return lv
+}
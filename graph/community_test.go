@@ -0,0 +1,97 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+// 两个 5 顶点的完全图, 中间用一条边相连
+func twoCliques() *Graph {
+	g := NewGraph(10)
+	for base := 0; base < 10; base += 5 {
+		for v := base; v < base+5; v++ {
+			for w := v + 1; w < base+5; w++ {
+				g.AddEdge(v, w)
+			}
+		}
+	}
+	g.AddEdge(4, 5)
+	return g
+}
+
+func assertTwoCliques(t *testing.T, c Communities) {
+	assert.Equal(t, 2, c.Count())
+	for v := 0; v < 5; v++ {
+		assert.True(t, c.Connected(0, v))
+		assert.True(t, c.Connected(5, v+5))
+	}
+	assert.False(t, c.Connected(0, 5))
+	// 每个完全图内部 10 条边, 总共 21 条边, 每个社区的度数之和为 21
+	assert.InDelta(t, 2*(10.0/21-0.25), c.Modularity(), 1e-9)
+}
+
+func TestLabelPropagation(t *testing.T) {
+	for seed := int64(0); seed < 5; seed++ {
+		assertTwoCliques(t, NewLabelPropagation(twoCliques(), seed))
+	}
+	c1 := NewLabelPropagation(NewGraphByData(data1), 1)
+	assert.Equal(t, 2, c1.Count())
+}
+
+func TestLouvain(t *testing.T) {
+	for seed := int64(0); seed < 5; seed++ {
+		assertTwoCliques(t, NewLouvain(twoCliques(), seed))
+	}
+	// 孤立顶点各自成为一个社区
+	g := NewGraph(3)
+	c := NewLouvain(g, 1)
+	assert.Equal(t, 3, c.Count())
+	assert.Equal(t, 0.0, c.Modularity())
+
+	// 邻接表的顺序每次都不同, 相同的 seed 仍然得到相同的划分
+	r := rand.New(rand.NewSource(1))
+	g = NewGraph(60)
+	for v := 0; v < 60; v++ {
+		for w := v + 1; w < 60; w++ {
+			if r.Float64() < 0.1 {
+				g.AddEdge(v, w)
+			}
+		}
+	}
+	first := NewLouvain(g, 42)
+	for i := 0; i < 30; i++ {
+		c := NewLouvain(g, 42)
+		assert.Equal(t, first.Modularity(), c.Modularity())
+		for v := 0; v < 60; v++ {
+			assert.Equal(t, first.ID(v), c.ID(v))
+		}
+	}
+}
+
+func TestWeightedLouvain(t *testing.T) {
+	// 4 个顶点的环, 权重决定了应该怎样切分
+	g := NewEdgeWeightedGraph(4)
+	g.AddEdge(Edge{0, 1, 10})
+	g.AddEdge(Edge{1, 2, 1})
+	g.AddEdge(Edge{2, 3, 10})
+	g.AddEdge(Edge{3, 0, 1})
+	c := NewWeightedLouvain(g, 1)
+	assert.Equal(t, 2, c.Count())
+	assert.True(t, c.Connected(0, 1))
+	assert.True(t, c.Connected(2, 3))
+	assert.False(t, c.Connected(1, 2))
+	assert.InDelta(t, Modularity(g, []int{0, 0, 1, 1}), c.Modularity(), 1e-12)
+
+	a := NewWeightedLouvain(g, 7)
+	b := NewWeightedLouvain(g, 7)
+	for v := 0; v < 4; v++ {
+		assert.Equal(t, a.ID(v), b.ID(v))
+	}
+}
+
+func TestModularity(t *testing.T) {
+	g := NewEdgeWeightedGraphByGraph(twoCliques())
+	all := make([]int, 10)
+	assert.InDelta(t, 0, Modularity(g, all), 1e-12)
+}
@@ -0,0 +1,101 @@
+package graph
+
+// Batagelj–Zaversnik 线性时间 k-core 分解
+// 顶点按度数装进桶里, 每次取出度数最小的顶点, 它的当前度数就是核数,
+// 然后把它的邻居的度数减一并移到相应的桶中, 时间复杂度 O(V+E)
+type CoresImpl struct {
+	adj        [][]int
+	core       []int
+	order      []int
+	degeneracy int
+}
+
+func NewCores(graph SimpleGraph) Cores {
+	n := graph.V()
+	c := &CoresImpl{adj: make([][]int, n), core: make([]int, n), order: make([]int, n)}
+	max := 0
+	for v := 0; v < n; v++ {
+		for _, w := range graph.Adj(v) {
+			if w != v { // 自环不计入度数
+				c.adj[v] = append(c.adj[v], w)
+			}
+		}
+		c.core[v] = len(c.adj[v])
+		if c.core[v] > max {
+			max = c.core[v]
+		}
+	}
+	// bin[d] 是度数为 d 的顶点在 order 中的起始位置, pos[v] 是 v 在 order 中的位置
+	bin := make([]int, max+1)
+	for _, d := range c.core {
+		bin[d]++
+	}
+	start := 0
+	for d := range bin {
+		bin[d], start = start, start+bin[d]
+	}
+	pos := make([]int, n)
+	for v, d := range c.core {
+		pos[v] = bin[d]
+		c.order[pos[v]] = v
+		bin[d]++
+	}
+	for d := max; d > 0; d-- {
+		bin[d] = bin[d-1]
+	}
+	bin[0] = 0
+	for i := 0; i < n; i++ {
+		v := c.order[i]
+		if c.core[v] > c.degeneracy {
+			c.degeneracy = c.core[v]
+		}
+		for _, w := range c.adj[v] {
+			if c.core[w] > c.core[v] {
+				// 把 w 和它所在的桶的第一个顶点交换, 然后把桶的起点后移, w 就落到了前一个桶里
+				dw := c.core[w]
+				pw, ps := pos[w], bin[dw]
+				s := c.order[ps]
+				if s != w {
+					c.order[pw], c.order[ps] = s, w
+					pos[w], pos[s] = ps, pw
+				}
+				bin[dw]++
+				c.core[w]--
+			}
+		}
+	}
+	return c
+}
+
+func (self *CoresImpl) Core(v int) int {
+	return self.core[v]
+}
+
+func (self *CoresImpl) Degeneracy() int {
+	return self.degeneracy
+}
+
+func (self *CoresImpl) Order() []int {
+	return self.order
+}
+
+func (self *CoresImpl) KCore(k int) (*Graph, []int) {
+	vertices := make([]int, 0)
+	index := make([]int, len(self.core))
+	for v, c := range self.core {
+		index[v] = -1
+		if c >= k {
+			index[v] = len(vertices)
+			vertices = append(vertices, v)
+		}
+	}
+	g := NewGraph(len(vertices))
+	for i, v := range vertices {
+		for _, w := range self.adj[v] {
+			if j := index[w]; j > i {
+				g.AddEdge(i, j)
+			}
+		}
+	}
+	return g, vertices
+}
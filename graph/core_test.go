@@ -0,0 +1,66 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestCores(t *testing.T) {
+	// 4 个顶点的完全图 0-3, 挂着一条路径 3-4-5, 再加一个孤立顶点 6
+	g := NewGraph(7)
+	for v := 0; v < 4; v++ {
+		for w := v + 1; w < 4; w++ {
+			g.AddEdge(v, w)
+		}
+	}
+	g.AddEdge(3, 4)
+	g.AddEdge(4, 5)
+	c := NewCores(g)
+	assert.Equal(t, 3, c.Degeneracy())
+	for v, want := range []int{3, 3, 3, 3, 1, 1, 0} {
+		assert.Equal(t, want, c.Core(v))
+	}
+	k, vertices := c.KCore(3)
+	assert.Equal(t, []int{0, 1, 2, 3}, vertices)
+	assert.Equal(t, 4, k.V())
+	assert.Equal(t, 6, k.E())
+	assertDegeneracyOrder(t, g, c)
+}
+
+func TestCoresRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	g := NewGraph(300)
+	for i := 0; i < 3000; i++ {
+		g.AddEdge(r.Intn(300), r.Intn(300))
+	}
+	c := NewCores(g)
+	assertDegeneracyOrder(t, g, c)
+	// k-core 中每个顶点的度数都至少为 k, 并且去掉 k-core 之外的顶点后核数不变
+	for k := 1; k <= c.Degeneracy(); k++ {
+		sub, vertices := c.KCore(k)
+		sc := NewCores(sub)
+		for i, v := range vertices {
+			assert.True(t, len(sub.Adj(i)) >= k)
+			assert.Equal(t, c.Core(v), sc.Core(i))
+		}
+	}
+}
+
+// 退化序中每个顶点排在它之后的邻居不超过退化度
+func assertDegeneracyOrder(t *testing.T, g SimpleGraph, c Cores) {
+	pos := make([]int, g.V())
+	for i, v := range c.Order() {
+		pos[v] = i
+	}
+	assert.Equal(t, g.V(), len(c.Order()))
+	for v := 0; v < g.V(); v++ {
+		later := 0
+		for _, w := range g.Adj(v) {
+			if pos[w] > pos[v] {
+				later++
+			}
+		}
+		assert.True(t, later <= c.Degeneracy())
+	}
+}
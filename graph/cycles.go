@@ -0,0 +1,389 @@
+package graph
+
+import (
+	"sort"
+)
+
+// Johnson 算法枚举有向图的所有初等环 (除首尾外顶点不重复的环)
+// 依次以 s = 0, 1, ... 为起点, 只在顶点编号不小于 s 的子图中 s 所在的强连通分量里找经过 s 的环,
+// 回溯时被阻塞的顶点直到从它出发能回到 s 时才解除阻塞, 时间复杂度 O((V+E)(C+1)), C 为环数
+// 每个环首尾是同一个顶点, 以环上编号最小的顶点开头, 依次回调 f, f 返回 false 时停止
+// maxLen > 0 时只枚举边数不超过 maxLen 的环, maxCount > 0 时最多回调 maxCount 次
+// 全部枚举完返回 true, 被 f 终止或回调了 maxCount 次之后还有环时返回 false
+func ElementaryCycles(dig SimpleDigraph, maxCount, maxLen int, f func(cycle []int) bool) bool {
+	j := newJohnson(dig)
+	j.report = limitCycles(maxCount, f)
+	j.maxLen = maxLen
+	for s := 0; s < len(j.adj); s++ {
+		if j.loop[s] && !j.report([]int{s, s}) {
+			return false
+		}
+		if j.component(s) && !j.circuit(s) {
+			return false
+		}
+	}
+	return true
+}
+
+type johnson struct {
+	adj, radj [][]int // 排好序的邻接表和反向邻接表, 不含自环
+	loop      []bool  // 有自环的顶点
+	comp      []int   // comp[v] == s 表示 v 属于当前的强连通分量
+	reach     []int   // 求分量时的正向可达标记
+	blocked   []bool
+	b         []map[int]bool // b[w] 中的顶点在 w 解除阻塞时跟着解除
+	maxLen    int
+	report    func([]int) bool
+}
+
+func newJohnson(dig SimpleDigraph) *johnson {
+	n := dig.V()
+	j := &johnson{
+		adj: make([][]int, n), radj: make([][]int, n), loop: make([]bool, n),
+		comp: make([]int, n), reach: make([]int, n), blocked: make([]bool, n), b: make([]map[int]bool, n),
+	}
+	for v := 0; v < n; v++ {
+		for _, w := range dig.Adj(v) {
+			if v == w {
+				j.loop[v] = true
+				continue
+			}
+			j.adj[v] = append(j.adj[v], w)
+			j.radj[w] = append(j.radj[w], v)
+		}
+	}
+	for v := 0; v < n; v++ {
+		sort.Ints(j.adj[v])
+		sort.Ints(j.radj[v])
+		j.comp[v], j.reach[v] = -1, -1
+	}
+	return j
+}
+
+// 在编号不小于 s 的子图中求 s 所在的强连通分量: 从 s 正向可达且能反向到达 s 的顶点,
+// 同时重置分量内顶点的阻塞状态, 分量只有 s 一个顶点时返回 false
+func (self *johnson) component(s int) bool {
+	mark := func(adj [][]int, seen []int, keep func(int) bool) {
+		seen[s] = s
+		queue := []int{s}
+		for i := 0; i < len(queue); i++ {
+			for _, w := range adj[queue[i]] {
+				if w > s && seen[w] != s && keep(w) {
+					seen[w] = s
+					queue = append(queue, w)
+				}
+			}
+		}
+	}
+	mark(self.adj, self.reach, func(int) bool { return true })
+	mark(self.radj, self.comp, func(w int) bool { return self.reach[w] == s })
+	size := 0
+	for v := s; v < len(self.comp); v++ {
+		if self.comp[v] == s {
+			self.blocked[v] = false
+			self.b[v] = nil
+			size++
+		}
+	}
+	return size > 1
+}
+
+// 以 s 为起点的回溯搜索, 用显式栈代替递归
+// found 表示从这个顶点出发找到过回到 s 的路径, 这时才解除它的阻塞;
+// 因为长度限制而没有展开的分支也当作找到过, 只会多解除一些阻塞, 不会漏掉环
+func (self *johnson) circuit(s int) bool {
+	type frame struct {
+		v, i  int
+		found bool
+	}
+	path := []int{s}
+	sk := []*frame{{v: s}}
+	self.blocked[s] = true
+	for len(sk) > 0 {
+		f := sk[len(sk)-1]
+		if f.i < len(self.adj[f.v]) {
+			w := self.adj[f.v][f.i]
+			f.i++
+			switch {
+			case self.comp[w] != s:
+			case w == s:
+				f.found = true
+				cycle := make([]int, len(path)+1)
+				copy(cycle, path)
+				cycle[len(path)] = s
+				if !self.report(cycle) {
+					return false
+				}
+			case self.blocked[w]:
+			case self.maxLen > 0 && len(path) >= self.maxLen:
+				f.found = true
+			default:
+				self.blocked[w] = true
+				path = append(path, w)
+				sk = append(sk, &frame{v: w})
+			}
+			continue
+		}
+		if f.found {
+			self.unblock(f.v)
+		} else {
+			for _, w := range self.adj[f.v] {
+				if self.comp[w] == s {
+					if self.b[w] == nil {
+						self.b[w] = make(map[int]bool)
+					}
+					self.b[w][f.v] = true
+				}
+			}
+		}
+		sk = sk[:len(sk)-1]
+		path = path[:len(path)-1]
+		if len(sk) > 0 && f.found {
+			sk[len(sk)-1].found = true
+		}
+	}
+	return true
+}
+
+func (self *johnson) unblock(u int) {
+	self.blocked[u] = false
+	work := []int{u}
+	for len(work) > 0 {
+		x := work[len(work)-1]
+		work = work[:len(work)-1]
+		for w := range self.b[x] {
+			delete(self.b[x], w)
+			if self.blocked[w] {
+				self.blocked[w] = false
+				work = append(work, w)
+			}
+		}
+	}
+}
+
+// 无向图的一组基本环 (环空间的基)
+// 对每个连通分量取广度优先生成树, 每条非树边和它两端之间的树路径组成一个环,
+// 环的个数是 E - V + C, 自环也各算一个; 每个环首尾是同一个顶点
+func CycleBasis(graph SimpleGraph) [][]int {
+	r := make([][]int, 0)
+	forest(graph, func(v, w int, cycle []int) {
+		r = append(r, cycle)
+	})
+	return r
+}
+
+// 生成森林, 对每条非树边 v-w (v <= w) 回调它对应的基本环 v ... w v
+func forest(graph SimpleGraph, f func(v, w int, cycle []int)) {
+	adj := adjacency(graph)
+	n := len(adj)
+	parent, depth := make([]int, n), make([]int, n)
+	for v := range depth {
+		depth[v] = -1
+	}
+	queue := make([]int, 0, n)
+	for s := 0; s < n; s++ {
+		if depth[s] >= 0 {
+			continue
+		}
+		parent[s], depth[s] = s, 0
+		queue = append(queue[:0], s)
+		for i := 0; i < len(queue); i++ {
+			v := queue[i]
+			for _, w := range adj[v] {
+				if depth[w] < 0 {
+					parent[w], depth[w] = v, depth[v]+1
+					queue = append(queue, w)
+				}
+			}
+		}
+	}
+	for v := 0; v < n; v++ {
+		for _, w := range adj[v] {
+			if w < v || v != w && (parent[w] == v || parent[v] == w) {
+				continue
+			}
+			// 两端同时沿树往上走到最近公共祖先
+			left, right := []int{v}, []int{w}
+			for a, b := v, w; a != b; {
+				if depth[a] >= depth[b] {
+					a = parent[a]
+					left = append(left, a)
+				} else {
+					b = parent[b]
+					right = append(right, b)
+				}
+			}
+			// left 和 right 的最后一个顶点都是公共祖先
+			cycle := left
+			for i := len(right) - 2; i >= 0; i-- {
+				cycle = append(cycle, right[i])
+			}
+			f(v, w, append(cycle, v))
+		}
+	}
+}
+
+// 枚举无向图的所有简单环
+// 环空间中的每个元素都是若干基本环的对称差, 按格雷码顺序遍历基本环的所有组合,
+// 每步只翻转一个基本环的边, 对称差恰好是一个环 (所有顶点度数为 2 且连通) 时回调,
+// 时间复杂度 O(2^m * V), m = E - V + C 是基本环的个数, 只适用于环不多的图
+// 每个环以编号最小的顶点开头, 第二个顶点比倒数第二个顶点小, 首尾是同一个顶点
+// maxLen, maxCount 和返回值的含义同 ElementaryCycles
+func AllCycles(graph SimpleGraph, maxCount, maxLen int, f func(cycle []int) bool) bool {
+	n := graph.V()
+	limited := limitCycles(maxCount, f)
+	report := func(cycle []int) bool {
+		if maxLen > 0 && len(cycle)-1 > maxLen {
+			return true
+		}
+		return limited(cycle)
+	}
+	// 基本环用边的编号表示
+	type edge struct{ v, w int }
+	index := make(map[edge]int)
+	edgeIndex := func(v, w int) int {
+		if v > w {
+			v, w = w, v
+		}
+		i, ok := index[edge{v, w}]
+		if !ok {
+			i = len(index)
+			index[edge{v, w}] = i
+		}
+		return i
+	}
+	basis := make([][]int, 0)
+	loops := make([]int, 0)
+	forest(graph, func(v, w int, cycle []int) {
+		if v == w {
+			loops = append(loops, v)
+			return
+		}
+		edges := make([]int, 0, len(cycle)-1)
+		for i := 0; i+1 < len(cycle); i++ {
+			edges = append(edges, edgeIndex(cycle[i], cycle[i+1]))
+		}
+		basis = append(basis, edges)
+	})
+	for _, v := range loops {
+		if !report([]int{v, v}) {
+			return false
+		}
+	}
+	ends := make([]edge, len(index))
+	for e, i := range index {
+		ends[i] = e
+	}
+	adj := make([][]int, n) // 顶点关联的边的编号
+	for i, e := range ends {
+		adj[e.v] = append(adj[e.v], i)
+		adj[e.w] = append(adj[e.w], i)
+	}
+	in := make([]bool, len(ends))
+	deg := make([]int, n)
+	bad, size := 0, 0 // bad 是度数不为 0 或 2 的顶点个数, size 是当前的边数
+	odd := func(d int) bool { return d != 0 && d != 2 }
+	// 格雷码第 k 步翻转的是 k 的最低位 1, 用按位存储的计数器代替整数, 基本环的个数没有上限
+	counter := make([]bool, len(basis))
+	for {
+		flip := 0
+		for flip < len(counter) && counter[flip] {
+			counter[flip] = false
+			flip++
+		}
+		if flip == len(counter) {
+			break
+		}
+		counter[flip] = true
+		for _, i := range basis[flip] {
+			in[i] = !in[i]
+			d := 1
+			if !in[i] {
+				d = -1
+			}
+			size += d
+			for _, x := range []int{ends[i].v, ends[i].w} {
+				before := odd(deg[x])
+				deg[x] += d
+				if after := odd(deg[x]); before != after {
+					if after {
+						bad++
+					} else {
+						bad--
+					}
+				}
+			}
+		}
+		if bad > 0 || size == 0 || maxLen > 0 && size > maxLen {
+			continue
+		}
+		// 从任一在环上的顶点出发沿边走一圈, 走回起点时用掉了所有的边才是一个环
+		start := -1
+		for _, i := range basis[flip] {
+			if x := ends[i].v; deg[x] == 2 {
+				start = x
+				break
+			}
+		}
+		for x := 0; start < 0; x++ {
+			if deg[x] == 2 {
+				start = x
+			}
+		}
+		cycle := []int{start}
+		prev := -1
+		for x := start; ; {
+			next := -1
+			for _, i := range adj[x] {
+				if in[i] && i != prev {
+					next, prev = i, i
+					break
+				}
+			}
+			x = ends[next].v + ends[next].w - x
+			if x == start {
+				break
+			}
+			cycle = append(cycle, x)
+		}
+		if len(cycle) != size {
+			continue
+		}
+		if !report(canonicalCycle(cycle)) {
+			return false
+		}
+	}
+	return true
+}
+
+// 最多回调 maxCount 次, 第 maxCount + 1 个环不回调 f, 直接返回 false 表示还有更多的环
+func limitCycles(maxCount int, f func([]int) bool) func([]int) bool {
+	count := 0
+	return func(cycle []int) bool {
+		if maxCount > 0 && count >= maxCount {
+			return false
+		}
+		count++
+		return f(cycle)
+	}
+}
+
+// 把环转成以最小顶点开头, 第二个顶点比最后一个顶点小, 并首尾闭合
+func canonicalCycle(cycle []int) []int {
+	k := len(cycle)
+	m := 0
+	for i, v := range cycle {
+		if v < cycle[m] {
+			m = i
+		}
+	}
+	step := 1
+	if cycle[(m+k-1)%k] < cycle[(m+1)%k] {
+		step = k - 1
+	}
+	r := make([]int, 0, k+1)
+	for i := 0; i < k; i++ {
+		r = append(r, cycle[(m+i*step)%k])
+	}
+	return append(r, cycle[m])
+}
@@ -0,0 +1,180 @@
+package graph
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+// 暴力枚举: 以环上最小的顶点为起点深度优先搜索所有简单路径
+// 无向图的每个环会沿两个方向各找到一次, 只保留第二个顶点比倒数第二个顶点小的那个方向
+func bruteCycles(adj [][]int, directed bool, maxLen int) map[string]bool {
+	r := make(map[string]bool)
+	n := len(adj)
+	onPath := make([]bool, n)
+	var path []int
+	var dfs func(s, v int)
+	dfs = func(s, v int) {
+		for _, w := range adj[v] {
+			switch {
+			case w == s && (directed || len(path) != 2):
+				if directed || len(path) == 1 || path[1] < path[len(path)-1] {
+					if maxLen <= 0 || len(path) <= maxLen {
+						r[fmt.Sprint(append(append([]int(nil), path...), s))] = true
+					}
+				}
+			case w > s && !onPath[w]:
+				onPath[w] = true
+				path = append(path, w)
+				dfs(s, w)
+				path = path[:len(path)-1]
+				onPath[w] = false
+			}
+		}
+	}
+	for s := 0; s < n; s++ {
+		path = []int{s}
+		onPath[s] = true
+		dfs(s, s)
+		onPath[s] = false
+	}
+	return r
+}
+
+func collectCycles(t *testing.T, enumerate func(f func([]int) bool) bool) map[string]bool {
+	r := make(map[string]bool)
+	assert.True(t, enumerate(func(cycle []int) bool {
+		key := fmt.Sprint(cycle)
+		assert.False(t, r[key], "duplicate %v", cycle)
+		r[key] = true
+		return true
+	}))
+	return r
+}
+
+func TestElementaryCycles(t *testing.T) {
+	// 完全有向图 K5 的初等环个数: C(5,2)*1! + C(5,3)*2! + C(5,4)*3! + C(5,5)*4! = 84
+	k5 := NewDigraph(5)
+	for v := 0; v < 5; v++ {
+		for w := 0; w < 5; w++ {
+			if v != w {
+				k5.AddEdge(v, w)
+			}
+		}
+	}
+	cycles := collectCycles(t, func(f func([]int) bool) bool { return ElementaryCycles(k5, 0, 0, f) })
+	assert.Equal(t, 84, len(cycles))
+	assert.True(t, cycles["[0 1 2 3 4 0]"])
+	cycles = collectCycles(t, func(f func([]int) bool) bool { return ElementaryCycles(k5, 0, 2, f) })
+	assert.Equal(t, 10, len(cycles))
+
+	count := 0
+	assert.False(t, ElementaryCycles(k5, 7, 0, func([]int) bool { count++; return true }))
+	assert.Equal(t, 7, count)
+	// 恰好枚举完时不算截断
+	count = 0
+	assert.True(t, ElementaryCycles(k5, 84, 0, func([]int) bool { count++; return true }))
+	assert.Equal(t, 84, count)
+	count = 0
+	assert.False(t, ElementaryCycles(k5, 83, 0, func([]int) bool { count++; return true }))
+	assert.Equal(t, 83, count)
+
+	d := NewDigraph(3)
+	d.AddEdge(0, 0)
+	d.AddEdge(0, 1)
+	d.AddEdge(1, 2)
+	cycles = collectCycles(t, func(f func([]int) bool) bool { return ElementaryCycles(d, 0, 0, f) })
+	assert.Equal(t, map[string]bool{"[0 0]": true}, cycles)
+	assert.True(t, ElementaryCycles(dag, 0, 0, func([]int) bool { return false }))
+}
+
+func TestElementaryCyclesRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 30; i++ {
+		n := 8
+		d := NewDigraph(n)
+		for j := 0; j < 20; j++ {
+			v, w := r.Intn(n), r.Intn(n)
+			if v != w {
+				d.AddEdge(v, w)
+			}
+		}
+		for _, maxLen := range []int{0, 3} {
+			want := bruteCycles(adjacency(d), true, maxLen)
+			got := collectCycles(t, func(f func([]int) bool) bool { return ElementaryCycles(d, 0, maxLen, f) })
+			assert.Equal(t, want, got)
+		}
+	}
+}
+
+func TestCycleBasis(t *testing.T) {
+	g := NewGraphByData(data1)
+	basis := CycleBasis(g)
+	cc := NewCC(g)
+	assert.Equal(t, g.E()-g.V()+cc.Count(), len(basis))
+	for _, c := range basis {
+		assertCycle(t, g, c, len(c)-1)
+	}
+	t.Log(basis)
+}
+
+func TestAllCycles(t *testing.T) {
+	// K4 有 4 个三角形和 3 个四边形, K5 有 10 + 15 + 12 个环
+	for n, want := range map[int]int{4: 7, 5: 37} {
+		g := NewGraph(n)
+		for v := 0; v < n; v++ {
+			for w := v + 1; w < n; w++ {
+				g.AddEdge(v, w)
+			}
+		}
+		cycles := collectCycles(t, func(f func([]int) bool) bool { return AllCycles(g, 0, 0, f) })
+		assert.Equal(t, want, len(cycles))
+	}
+	g := NewGraph(4)
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 0)
+	g.AddEdge(3, 3)
+	cycles := collectCycles(t, func(f func([]int) bool) bool { return AllCycles(g, 0, 0, f) })
+	assert.Equal(t, map[string]bool{"[3 3]": true, "[0 1 2 0]": true}, cycles)
+	assert.True(t, AllCycles(g, 2, 0, func([]int) bool { return true }))
+	assert.False(t, AllCycles(g, 1, 0, func([]int) bool { return true }))
+
+	// 9x9 网格有 64 个基本环, 超过一个机器字也能枚举, 达到 maxCount 时提前停止
+	grid := NewGraph(81)
+	for v := 0; v < 81; v++ {
+		if v%9 < 8 {
+			grid.AddEdge(v, v+1)
+		}
+		if v < 72 {
+			grid.AddEdge(v, v+9)
+		}
+	}
+	count := 0
+	assert.False(t, AllCycles(grid, 10, 0, func(cycle []int) bool {
+		assertCycle(t, grid, cycle, len(cycle)-1)
+		count++
+		return true
+	}))
+	assert.Equal(t, 10, count)
+}
+
+func TestAllCyclesRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 30; i++ {
+		n := 9
+		g := NewGraph(n)
+		for j := 0; j < 14; j++ {
+			v, w := r.Intn(n), r.Intn(n)
+			if v != w {
+				g.AddEdge(v, w)
+			}
+		}
+		for _, maxLen := range []int{0, 4} {
+			want := bruteCycles(adjacency(g), false, maxLen)
+			got := collectCycles(t, func(f func([]int) bool) bool { return AllCycles(g, 0, maxLen, f) })
+			assert.Equal(t, want, got)
+		}
+	}
+}
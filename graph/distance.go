@@ -0,0 +1,222 @@
+package graph
+
+// 从每个顶点做一次广度优先搜索, 时间复杂度 O(VE)
+type DistancesImpl struct {
+	ecc      []int
+	diameter int
+	radius   int
+	path     []int
+	girth    int
+	cycle    []int
+}
+
+func NewDistances(graph SimpleGraph) Distances {
+	n := graph.V()
+	adj := adjacency(graph)
+	directed := isDirected(graph)
+	d := &DistancesImpl{ecc: make([]int, n)}
+	dist, edgeTo, queue := make([]int, n), make([]int, n), make([]int, 0, n)
+	from, to := 0, 0
+	for s := 0; s < n; s++ {
+		queue = bfsTree(adj, s, dist, edgeTo, queue)
+		far := queue[len(queue)-1]
+		d.ecc[s] = dist[far]
+		if s == 0 || d.ecc[s] > d.diameter {
+			d.diameter, from, to = d.ecc[s], s, far
+		}
+		if s == 0 || d.ecc[s] < d.radius {
+			d.radius = d.ecc[s]
+		}
+		d.shortestCycle(adj, s, directed, dist, edgeTo, queue)
+	}
+	if n > 0 {
+		bfsTree(adj, from, dist, edgeTo, queue)
+		d.path = treePath(edgeTo, from, to)
+	}
+	return d
+}
+
+// 从 s 出发的广度优先树, 不可达的顶点距离为 -1, 返回按距离排序的可达顶点
+func bfsTree(adj [][]int, s int, dist, edgeTo, queue []int) []int {
+	for i := range dist {
+		dist[i] = -1
+	}
+	dist[s], edgeTo[s] = 0, s
+	queue = append(queue[:0], s)
+	for i := 0; i < len(queue); i++ {
+		v := queue[i]
+		for _, w := range adj[v] {
+			if dist[w] < 0 {
+				dist[w], edgeTo[w] = dist[v]+1, v
+				queue = append(queue, w)
+			}
+		}
+	}
+	return queue
+}
+
+// 广度优先树上 s 到 v 的路径
+func treePath(edgeTo []int, s, v int) []int {
+	r := make([]int, 0)
+	for x := v; x != s; x = edgeTo[x] {
+		r = append(r, x)
+	}
+	r = append(r, s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return r
+}
+
+// 用以 s 为根的广度优先树找经过 s 附近的最短环, 对所有 s 取最小值就是围长
+// 无向图: 非树边 v-w 构成长度为 dist[v]+dist[w]+1 的闭合路径,
+// 取到全局最小值时两条树路径除 s 外不相交, 所以一定是简单环
+// 有向图: 指回 s 的边 v->s 构成长度为 dist[v]+1 的环
+func (self *DistancesImpl) shortestCycle(adj [][]int, s int, directed bool, dist, edgeTo, queue []int) {
+	for _, v := range queue {
+		for _, w := range adj[v] {
+			length := 0
+			switch {
+			case w == v:
+				length = 1
+			case directed && w == s:
+				length = dist[v] + 1
+			case !directed && dist[w] >= dist[v] && w != edgeTo[v] && v != edgeTo[w]:
+				length = dist[v] + dist[w] + 1
+			}
+			if length == 0 || (self.girth > 0 && length >= self.girth) {
+				continue
+			}
+			self.girth = length
+			switch {
+			case w == v:
+				self.cycle = []int{v, v}
+			case directed:
+				self.cycle = append(treePath(edgeTo, s, v), s)
+			default:
+				// s..v 再沿 w..s 回到 s
+				self.cycle = treePath(edgeTo, s, v)
+				back := treePath(edgeTo, s, w)
+				for i := len(back) - 1; i >= 0; i-- {
+					self.cycle = append(self.cycle, back[i])
+				}
+			}
+		}
+	}
+}
+
+func (self *DistancesImpl) Eccentricity(v int) int {
+	return self.ecc[v]
+}
+
+func (self *DistancesImpl) Diameter() int {
+	return self.diameter
+}
+
+func (self *DistancesImpl) DiameterPath() []int {
+	return self.path
+}
+
+func (self *DistancesImpl) Radius() int {
+	return self.radius
+}
+
+func (self *DistancesImpl) Center() []int {
+	r := make([]int, 0)
+	for v, e := range self.ecc {
+		if e == self.radius {
+			r = append(r, v)
+		}
+	}
+	return r
+}
+
+func (self *DistancesImpl) Periphery() []int {
+	r := make([]int, 0)
+	for v, e := range self.ecc {
+		if e == self.diameter {
+			r = append(r, v)
+		}
+	}
+	return r
+}
+
+func (self *DistancesImpl) Girth() int {
+	return self.girth
+}
+
+func (self *DistancesImpl) GirthCycle() []int {
+	return self.cycle
+}
+
+// iFUB (iterative Fringe Upper Bound) 计算无向图的直径, 返回直径和两个端点
+// 先用 4-sweep 选一个接近中心的顶点 u, 再从离 u 最远的一层开始,
+// 逐层计算离心率作为下界; 第 i 层及以内的两个顶点相距不超过 2i, 下界达到 2i 时就可以停止
+// 实际图上通常只需要少量的广度优先搜索, 但像网格这样最短路径很多的图可能退化到 O(VE)
+// 不连通的图对每个连通分量分别计算
+func FastDiameter(graph SimpleGraph) (diameter, from, to int) {
+	n := graph.V()
+	adj := adjacency(graph)
+	dist, edgeTo, queue := make([]int, n), make([]int, n), make([]int, 0, n)
+	seen := make([]bool, n)
+	for s := 0; s < n; s++ {
+		if seen[s] {
+			continue
+		}
+		queue = bfsTree(adj, s, dist, edgeTo, queue)
+		component := append([]int(nil), queue...)
+		for _, v := range component {
+			seen[v] = true
+		}
+		d, a, b := ifub(adj, component, dist, edgeTo, queue)
+		if d > diameter || s == 0 {
+			diameter, from, to = d, a, b
+		}
+	}
+	return
+}
+
+// 在一个连通分量上运行 iFUB
+func ifub(adj [][]int, component []int, dist, edgeTo, queue []int) (lb, from, to int) {
+	// 4-sweep: 从度数最大的顶点出发做两次扫描, 取路径中点, 再重复一次
+	r := component[0]
+	for _, v := range component {
+		if len(adj[v]) > len(adj[r]) {
+			r = v
+		}
+	}
+	sweep := func(s int) (mid int) {
+		queue = bfsTree(adj, s, dist, edgeTo, queue)
+		a := queue[len(queue)-1]
+		queue = bfsTree(adj, a, dist, edgeTo, queue)
+		b := queue[len(queue)-1]
+		if dist[b] > lb {
+			lb, from, to = dist[b], a, b
+		}
+		path := treePath(edgeTo, a, b)
+		return path[len(path)/2]
+	}
+	u := sweep(sweep(r))
+
+	queue = bfsTree(adj, u, dist, edgeTo, queue)
+	eccU := dist[queue[len(queue)-1]]
+	if eccU > lb {
+		lb, from, to = eccU, u, queue[len(queue)-1]
+	}
+	// 按到 u 的距离分层
+	levels := make([][]int, eccU+1)
+	for _, v := range queue {
+		levels[dist[v]] = append(levels[dist[v]], v)
+	}
+	vdist, vedge, vqueue := make([]int, len(dist)), make([]int, len(dist)), make([]int, 0)
+	for i := eccU; i > 0 && lb < 2*i; i-- {
+		for _, v := range levels[i] {
+			vqueue = bfsTree(adj, v, vdist, vedge, vqueue)
+			far := vqueue[len(vqueue)-1]
+			if vdist[far] > lb {
+				lb, from, to = vdist[far], v, far
+			}
+		}
+	}
+	return
+}
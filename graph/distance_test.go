@@ -0,0 +1,106 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestDistances(t *testing.T) {
+	d := NewDistances(pathGraph(5))
+	assert.Equal(t, 4, d.Eccentricity(0))
+	assert.Equal(t, 2, d.Eccentricity(2))
+	assert.Equal(t, 4, d.Diameter())
+	assert.Equal(t, 2, d.Radius())
+	assert.Equal(t, []int{2}, d.Center())
+	assert.Equal(t, []int{0, 4}, d.Periphery())
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, d.DiameterPath())
+	assert.Equal(t, 0, d.Girth())
+	assert.Nil(t, d.GirthCycle())
+
+	// data2 中最短的环是三角形
+	d = NewDistances(NewGraphByAdjacencyList(data2))
+	assert.Equal(t, 3, d.Girth())
+	assertCycle(t, NewGraphByAdjacencyList(data2), d.GirthCycle(), 3)
+	assert.Equal(t, 2, d.Diameter())
+	assert.Equal(t, 3, len(d.DiameterPath()))
+}
+
+func TestGirth(t *testing.T) {
+	// 6 个顶点的环加上一条弦 0-3, 最短的环长度为 4
+	g := pathGraph(6)
+	g.AddEdge(5, 0)
+	g.AddEdge(0, 3)
+	d := NewDistances(g)
+	assert.Equal(t, 4, d.Girth())
+	assertCycle(t, g, d.GirthCycle(), 4)
+
+	dig := NewDigraph(5)
+	dig.AddEdge(0, 1)
+	dig.AddEdge(1, 2)
+	dig.AddEdge(2, 3)
+	dig.AddEdge(3, 0)
+	dig.AddEdge(2, 4)
+	dig.AddEdge(4, 1)
+	d = NewDistances(dig)
+	assert.Equal(t, 3, d.Girth())
+	assertCycle(t, dig, d.GirthCycle(), 3)
+	assert.Equal(t, 3, d.Eccentricity(0))
+}
+
+// cycle 必须是图中长度为 length 的简单环, 首尾相同
+func assertCycle(t *testing.T, g SimpleGraph, cycle []int, length int) {
+	assert.Equal(t, length+1, len(cycle))
+	assert.Equal(t, cycle[0], cycle[len(cycle)-1])
+	seen := make(map[int]bool)
+	for i := 0; i+1 < len(cycle); i++ {
+		assert.False(t, seen[cycle[i]])
+		seen[cycle[i]] = true
+		assert.Contains(t, g.Adj(cycle[i]), cycle[i+1])
+	}
+}
+
+func TestFastDiameter(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		g := NewGraph(200)
+		for v := 1; v < 200; v++ {
+			g.AddEdge(v, r.Intn(v)) // 随机树保证连通
+		}
+		for j := 0; j < i*5; j++ {
+			g.AddEdge(r.Intn(200), r.Intn(200))
+		}
+		d := NewDistances(g)
+		diameter, from, to := FastDiameter(g)
+		assert.Equal(t, d.Diameter(), diameter)
+		assert.Equal(t, diameter, len(new(BFSearch).GenSearch(g, from).PathTo(to))-1)
+	}
+	// 小的随机图, 不是树, 和逐个顶点广度优先的结果对照
+	for i := 0; i < 500; i++ {
+		n := 2 + r.Intn(15)
+		g := NewGraph(n)
+		for j := r.Intn(2 * n); j >= 0; j-- {
+			if v, w := r.Intn(n), r.Intn(n); v != w {
+				g.AddEdge(v, w)
+			}
+		}
+		diameter, from, to := FastDiameter(g)
+		assert.Equal(t, NewDistances(g).Diameter(), diameter)
+		assert.Equal(t, diameter, len(new(BFSearch).GenSearch(g, from).PathTo(to))-1)
+	}
+	// 各层的顶点可能相距 2i, 不能在处理第 i 层之前停止
+	g := NewGraph(12)
+	for _, e := range [][2]int{{0, 5}, {1, 11}, {3, 8}, {3, 10}, {4, 6}, {4, 9}, {4, 11}, {8, 11}, {9, 10}, {9, 11}} {
+		g.AddEdge(e[0], e[1])
+	}
+	diameter, _, _ := FastDiameter(g)
+	assert.Equal(t, 4, diameter)
+	// 不连通的图取各个连通分量中最大的直径
+	g = NewGraph(10)
+	g.AddEdge(0, 1)
+	for v := 3; v < 9; v++ {
+		g.AddEdge(v, v+1)
+	}
+	diameter, _, _ = FastDiameter(g)
+	assert.Equal(t, 6, diameter)
+}
@@ -0,0 +1,165 @@
+package graph
+
+import (
+	"sort"
+)
+
+// Cooper–Harvey–Kennedy 迭代算法计算支配树
+// 按逆后序反复用前驱的直接支配者的交集更新 idom, 直到不再变化,
+// 求交集时沿着 idom 向上走, 比较的是后序编号
+type DominatorsImpl struct {
+	root     int
+	idom     []int
+	children [][]int
+	pre      []int // 支配树的前序编号, 用来 O(1) 判断支配关系
+	post     []int
+	frontier [][]int
+}
+
+// 以 root 为根的支配树
+func NewDominators(dig SimpleDigraph, root int) Dominators {
+	n := dig.V()
+	d := &DominatorsImpl{root: root, idom: make([]int, n), children: make([][]int, n), frontier: make([][]int, n)}
+	// 从根出发的后序, 只包含可达的顶点
+	postNum := make([]int, n)
+	for v := range postNum {
+		postNum[v] = -1
+		d.idom[v] = -1
+	}
+	post := make([]int, 0)
+	DirectedDFSVisit(dig, &VisitorFuncs{
+		OnFinishVertex: func(v int) VisitResult {
+			postNum[v] = len(post)
+			post = append(post, v)
+			return VisitContinue
+		},
+	}, root)
+	preds := make([][]int, n)
+	for _, v := range post {
+		for _, w := range dig.Adj(v) {
+			preds[w] = append(preds[w], v)
+		}
+	}
+	intersect := func(a, b int) int {
+		for a != b {
+			for postNum[a] < postNum[b] {
+				a = d.idom[a]
+			}
+			for postNum[b] < postNum[a] {
+				b = d.idom[b]
+			}
+		}
+		return a
+	}
+	d.idom[root] = root
+	for changed := true; changed; {
+		changed = false
+		for i := len(post) - 2; i >= 0; i-- { // 逆后序, 跳过根
+			b := post[i]
+			idom := -1
+			for _, p := range preds[b] {
+				if d.idom[p] < 0 {
+					continue
+				}
+				if idom < 0 {
+					idom = p
+				} else {
+					idom = intersect(p, idom)
+				}
+			}
+			if d.idom[b] != idom {
+				d.idom[b] = idom
+				changed = true
+			}
+		}
+	}
+	// 支配边界: 对有多个前驱的 b (以及有前驱的根), 从每个前驱沿 idom 向上走到 idom(b), 路过的顶点的边界都包含 b
+	for _, b := range post {
+		stop := d.idom[b]
+		if b == root { // 指回根的边, 根的边界包含它自己
+			stop = -1
+		} else if len(preds[b]) < 2 {
+			continue
+		}
+		for _, p := range preds[b] {
+			for runner := p; runner != stop; runner = d.idom[runner] {
+				if f := d.frontier[runner]; len(f) > 0 && f[len(f)-1] == b {
+					break
+				}
+				d.frontier[runner] = append(d.frontier[runner], b)
+				if runner == root {
+					break
+				}
+			}
+		}
+	}
+	for v := range d.frontier {
+		sort.Ints(d.frontier[v])
+	}
+	d.idom[root] = -1
+	for _, v := range post {
+		if p := d.idom[v]; p >= 0 {
+			d.children[p] = append(d.children[p], v)
+		}
+	}
+	for v := range d.children {
+		sort.Ints(d.children[v])
+	}
+	d.number()
+	return d
+}
+
+// 后支配树: 从 v 到 exit 的每条路径都经过 a 时称 a 后支配 v
+// 就是反向图上以 exit 为根的支配树, Frontier 即后支配边界 (控制依赖)
+func NewPostDominators(dig SimpleDigraph, exit int) Dominators {
+	return NewDominators(dig.Reverse(), exit)
+}
+
+// 给支配树编前序和后序号
+func (self *DominatorsImpl) number() {
+	n := len(self.idom)
+	self.pre, self.post = make([]int, n), make([]int, n)
+	for v := range self.pre {
+		self.pre[v], self.post[v] = -1, -1
+	}
+	time := 0
+	sk := []*dfsFrame{{v: self.root, adj: self.children[self.root]}}
+	self.pre[self.root] = time
+	time++
+	for len(sk) > 0 {
+		f := sk[len(sk)-1]
+		w, ok := f.next()
+		if !ok {
+			self.post[f.v] = time
+			time++
+			sk = sk[:len(sk)-1]
+			continue
+		}
+		self.pre[w] = time
+		time++
+		sk = append(sk, &dfsFrame{v: w, adj: self.children[w]})
+	}
+}
+
+func (self *DominatorsImpl) Root() int {
+	return self.root
+}
+
+func (self *DominatorsImpl) IDom(v int) int {
+	return self.idom[v]
+}
+
+func (self *DominatorsImpl) Dominates(a, b int) bool {
+	if self.pre[a] < 0 || self.pre[b] < 0 {
+		return false
+	}
+	return self.pre[a] <= self.pre[b] && self.post[b] <= self.post[a]
+}
+
+func (self *DominatorsImpl) Children(v int) []int {
+	return self.children[v]
+}
+
+func (self *DominatorsImpl) Frontier(v int) []int {
+	return self.frontier[v]
+}
@@ -0,0 +1,92 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+// 控制流图: 0->1, 1->2, 1->3, 2->4, 3->4, 4->1 (循环), 4->5, 6 从根不可达
+func cfg() *Digraph {
+	d := NewDigraph(7)
+	d.AddEdge(0, 1)
+	d.AddEdge(1, 2)
+	d.AddEdge(1, 3)
+	d.AddEdge(2, 4)
+	d.AddEdge(3, 4)
+	d.AddEdge(4, 1)
+	d.AddEdge(4, 5)
+	d.AddEdge(6, 5)
+	return d
+}
+
+func TestDominators(t *testing.T) {
+	d := NewDominators(cfg(), 0)
+	assert.Equal(t, 0, d.Root())
+	for v, want := range []int{-1, 0, 1, 1, 1, 4, -1} {
+		assert.Equal(t, want, d.IDom(v), "idom(%d)", v)
+	}
+	assert.True(t, d.Dominates(1, 5))
+	assert.True(t, d.Dominates(4, 4))
+	assert.False(t, d.Dominates(2, 4))
+	assert.False(t, d.Dominates(0, 6))
+	assert.Equal(t, []int{2, 3, 4}, d.Children(1))
+	assert.Equal(t, []int{4}, d.Frontier(2))
+	assert.Equal(t, []int{4}, d.Frontier(3))
+	assert.Equal(t, []int{1}, d.Frontier(4))
+	assert.Equal(t, []int{1}, d.Frontier(1))
+	assert.Empty(t, d.Frontier(0))
+}
+
+func TestPostDominators(t *testing.T) {
+	g := cfg()
+	pd := NewPostDominators(g, 5)
+	assert.Equal(t, 4, pd.IDom(2))
+	assert.Equal(t, 4, pd.IDom(1))
+	assert.Equal(t, 1, pd.IDom(0))
+	assert.Equal(t, 5, pd.IDom(6))
+	// 2 和 3 控制依赖于 1
+	assert.Equal(t, []int{1}, pd.Frontier(2))
+	assert.Equal(t, []int{1}, pd.Frontier(3))
+}
+
+func TestDominatorsRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		g := NewDigraph(25)
+		for j := 0; j < 50; j++ {
+			g.AddEdge(r.Intn(25), r.Intn(25))
+		}
+		d := NewDominators(g, 0)
+		reach := new(DirectedSearchDFS).GenSearch(g, 0)
+		// 对照: a 支配 b 当且仅当删掉 a 之后 b 从根不可达
+		for a := 0; a < 25; a++ {
+			without := NewDigraph(25)
+			for v := 0; v < 25; v++ {
+				for _, w := range g.Adj(v) {
+					if v != a && w != a {
+						without.AddEdge(v, w)
+					}
+				}
+			}
+			rs := new(DirectedSearchDFS).GenSearch(without, 0)
+			for b := 0; b < 25; b++ {
+				want := reach.Marked(b) && (a == b || a == 0 || !rs.Marked(b))
+				assert.Equal(t, want, d.Dominates(a, b), "dominates(%d, %d)", a, b)
+			}
+		}
+		// 支配边界的定义: a 支配 y 的某个前驱, 但不严格支配 y
+		for a := 0; a < 25; a++ {
+			want := []int{}
+			for y := 0; y < 25; y++ {
+				for p := 0; p < 25; p++ {
+					if contains(g.Adj(p), y) && d.Dominates(a, p) && !(d.Dominates(a, y) && a != y) {
+						want = append(want, y)
+						break
+					}
+				}
+			}
+			assert.ElementsMatch(t, want, d.Frontier(a), "frontier(%d)", a)
+		}
+	}
+}
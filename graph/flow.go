@@ -0,0 +1,219 @@
+package graph
+
+import (
+	"bytes"
+	"container/heap"
+	"fmt"
+	"math"
+)
+
+// 流网络中的有向边, 容量和单位费用都是整数
+type FlowEdge struct {
+	From, To int
+	Cap      int
+	Cost     int
+}
+
+// 流网络: 有向图, 边带有容量和单位费用, 允许平行边
+type FlowNetwork struct {
+	v     int
+	edges []FlowEdge
+	adj   [][]int // 从 v 出发的边的编号
+}
+
+func NewFlowNetwork(v int) *FlowNetwork {
+	return &FlowNetwork{v: v, edges: make([]FlowEdge, 0), adj: make([][]int, v)}
+}
+
+func (self *FlowNetwork) V() int {
+	return self.v
+}
+
+func (self *FlowNetwork) E() int {
+	return len(self.edges)
+}
+
+// 加入容量为 capacity, 单位费用为 cost 的边 v->w, 返回边的编号
+func (self *FlowNetwork) AddEdge(v, w, capacity, cost int) int {
+	if v >= self.v || w >= self.v || capacity < 0 {
+		panic("error number")
+	}
+	id := len(self.edges)
+	self.edges = append(self.edges, FlowEdge{v, w, capacity, cost})
+	self.adj[v] = append(self.adj[v], id)
+	return id
+}
+
+func (self *FlowNetwork) Edge(id int) FlowEdge {
+	return self.edges[id]
+}
+
+func (self *FlowNetwork) Edges() []FlowEdge {
+	return self.edges
+}
+
+func (self *FlowNetwork) String() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "\n%d\n%d\n", self.v, len(self.edges))
+	for _, e := range self.edges {
+		fmt.Fprintf(&buf, "%d %d %d %d\n", e.From, e.To, e.Cap, e.Cost)
+	}
+	return buf.String()
+}
+
+// 连续最短路求最小费用流
+// 每次在残量网络中找一条费用最小的增广路, 沿它推送尽可能多的流量, 直到达到 limit 或没有增广路
+// 顶点势 h 使得残量网络中每条边的约化费用 cost + h[u] - h[v] 非负, 于是可以用 Dijkstra 找最短路;
+// 有负费用的边时先用 Bellman-Ford 求初始的势, 网络中不能有负费用的环
+// limit < 0 时求最小费用最大流, 时间复杂度 O(F * E log V), F 为增广次数
+// s == t 时没有增广路, 结果为空流
+type MinCostFlowImpl struct {
+	flow, cost int
+	flows      []int
+}
+
+func NewMinCostFlow(net *FlowNetwork, s, t, limit int) MinCostFlow {
+	n, m := net.V(), net.E()
+	// 残量网络: 边 2i 是原来的第 i 条边, 2i+1 是它的反向边
+	to, cap, cost := make([]int, 2*m), make([]int, 2*m), make([]int, 2*m)
+	adj := make([][]int, n)
+	for i, e := range net.edges {
+		to[2*i], cap[2*i], cost[2*i] = e.To, e.Cap, e.Cost
+		to[2*i+1], cost[2*i+1] = e.From, -e.Cost
+		adj[e.From] = append(adj[e.From], 2*i)
+		adj[e.To] = append(adj[e.To], 2*i+1)
+	}
+	from := func(e int) int { return to[e^1] }
+	f := &MinCostFlowImpl{flows: make([]int, m)}
+	if s == t {
+		return f
+	}
+	h := make([]int, n)
+	for _, e := range net.edges {
+		if e.Cost < 0 {
+			h = bellmanFord(n, s, to, cap, cost, from)
+			break
+		}
+	}
+	dist, edgeTo := make([]int, n), make([]int, n)
+	for limit < 0 || f.flow < limit {
+		// 在约化费用下求 s 出发的最短路
+		for v := range dist {
+			dist[v], edgeTo[v] = math.MaxInt64, -1
+		}
+		dist[s] = 0
+		pq := &flowHeap{{s, 0}}
+		for pq.Len() > 0 {
+			it := heap.Pop(pq).(flowItem)
+			v := it.v
+			if it.d > dist[v] {
+				continue
+			}
+			for _, e := range adj[v] {
+				w := to[e]
+				if cap[e] == 0 {
+					continue
+				}
+				if d := dist[v] + cost[e] + h[v] - h[w]; d < dist[w] {
+					dist[w], edgeTo[w] = d, e
+					heap.Push(pq, flowItem{w, d})
+				}
+			}
+		}
+		if dist[t] == math.MaxInt64 {
+			break
+		}
+		// 从 s 不可达的顶点以后也不会可达, 它们的势不需要更新
+		for v := range h {
+			if dist[v] < math.MaxInt64 {
+				h[v] += dist[v]
+			}
+		}
+		push := math.MaxInt64
+		if limit >= 0 {
+			push = limit - f.flow
+		}
+		for v := t; v != s; v = from(edgeTo[v]) {
+			if c := cap[edgeTo[v]]; c < push {
+				push = c
+			}
+		}
+		for v := t; v != s; v = from(edgeTo[v]) {
+			e := edgeTo[v]
+			cap[e] -= push
+			cap[e^1] += push
+			f.cost += push * cost[e]
+		}
+		f.flow += push
+	}
+	for i := range f.flows {
+		f.flows[i] = cap[2*i+1]
+	}
+	return f
+}
+
+// 有负费用时求初始的势, 即 s 到各顶点的最短距离, 不可达的顶点为 0
+func bellmanFord(n, s int, to, cap, cost []int, from func(int) int) []int {
+	dist := make([]int, n)
+	for v := range dist {
+		dist[v] = math.MaxInt64
+	}
+	dist[s] = 0
+	for i := 0; i < n; i++ {
+		changed := false
+		for e := range to {
+			if cap[e] == 0 || dist[from(e)] == math.MaxInt64 {
+				continue
+			}
+			if d := dist[from(e)] + cost[e]; d < dist[to[e]] {
+				dist[to[e]] = d
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+		if i == n-1 {
+			panic("negative cost cycle")
+		}
+	}
+	for v := range dist {
+		if dist[v] == math.MaxInt64 {
+			dist[v] = 0
+		}
+	}
+	return dist
+}
+
+type flowItem struct {
+	v, d int
+}
+
+type flowHeap []flowItem
+
+func (self flowHeap) Len() int            { return len(self) }
+func (self flowHeap) Less(i, j int) bool  { return self[i].d < self[j].d }
+func (self flowHeap) Swap(i, j int)       { self[i], self[j] = self[j], self[i] }
+func (self *flowHeap) Push(x interface{}) { *self = append(*self, x.(flowItem)) }
+func (self *flowHeap) Pop() interface{} {
+	old := *self
+	it := old[len(old)-1]
+	*self = old[:len(old)-1]
+	return it
+}
+
+func (self *MinCostFlowImpl) Flow() int {
+	return self.flow
+}
+
+func (self *MinCostFlowImpl) Cost() int {
+	return self.cost
+}
+
+func (self *MinCostFlowImpl) EdgeFlow(id int) int {
+	return self.flows[id]
+}
+
+func (self *MinCostFlowImpl) Flows() []int {
+	return self.flows
+}
@@ -0,0 +1,151 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+// 检查容量限制和流量守恒, 返回总费用
+func assertFlow(t *testing.T, net *FlowNetwork, s, tt int, f MinCostFlow) int {
+	excess := make([]int, net.V())
+	cost := 0
+	for id, e := range net.Edges() {
+		x := f.EdgeFlow(id)
+		assert.True(t, x >= 0 && x <= e.Cap, "edge %d", id)
+		excess[e.From] -= x
+		excess[e.To] += x
+		cost += x * e.Cost
+	}
+	for v, x := range excess {
+		switch v {
+		case s:
+			assert.Equal(t, -f.Flow(), x)
+		case tt:
+			assert.Equal(t, f.Flow(), x)
+		default:
+			assert.Equal(t, 0, x, "vertex %d", v)
+		}
+	}
+	assert.Equal(t, f.Cost(), cost)
+	return cost
+}
+
+// 对照: 每次用 Bellman-Ford 在残量网络上找最短路, 每次只推一个单位
+func bruteMinCostFlow(net *FlowNetwork, s, t, limit int) (int, int) {
+	m := net.E()
+	to, cap, cost := make([]int, 2*m), make([]int, 2*m), make([]int, 2*m)
+	for i, e := range net.Edges() {
+		to[2*i], cap[2*i], cost[2*i] = e.To, e.Cap, e.Cost
+		to[2*i+1], cost[2*i+1] = e.From, -e.Cost
+	}
+	flow, total := 0, 0
+	for limit < 0 || flow < limit {
+		dist, edgeTo := make([]int, net.V()), make([]int, net.V())
+		for v := range dist {
+			dist[v] = 1 << 60
+		}
+		dist[s] = 0
+		for i := 0; i < net.V(); i++ {
+			for e := range to {
+				if u := to[e^1]; cap[e] > 0 && dist[u] < 1<<60 && dist[u]+cost[e] < dist[to[e]] {
+					dist[to[e]], edgeTo[to[e]] = dist[u]+cost[e], e
+				}
+			}
+		}
+		if dist[t] == 1<<60 {
+			break
+		}
+		for v := t; v != s; v = to[edgeTo[v]^1] {
+			cap[edgeTo[v]]--
+			cap[edgeTo[v]^1]++
+		}
+		flow++
+		total += dist[t]
+	}
+	return flow, total
+}
+
+func TestMinCostFlow(t *testing.T) {
+	// 0->1 (2, 1), 0->2 (1, 2), 1->2 (1, 1), 1->3 (1, 3), 2->3 (2, 1)
+	net := NewFlowNetwork(4)
+	net.AddEdge(0, 1, 2, 1)
+	net.AddEdge(0, 2, 1, 2)
+	net.AddEdge(1, 2, 1, 1)
+	net.AddEdge(1, 3, 1, 3)
+	net.AddEdge(2, 3, 2, 1)
+	t.Log(net)
+	f := NewMinCostFlow(net, 0, 3, -1)
+	assert.Equal(t, 3, f.Flow())
+	assert.Equal(t, 10, f.Cost())
+	assertFlow(t, net, 0, 3, f)
+
+	f = NewMinCostFlow(net, 0, 3, 1)
+	assert.Equal(t, 1, f.Flow())
+	assert.Equal(t, 3, f.Cost())
+	assertFlow(t, net, 0, 3, f)
+
+	f = NewMinCostFlow(net, 3, 0, -1)
+	assert.Equal(t, 0, f.Flow())
+	f = NewMinCostFlow(net, 1, 1, -1)
+	assert.Equal(t, 0, f.Flow())
+	assert.Equal(t, 0, f.Cost())
+	assert.Equal(t, []int{0, 0, 0, 0, 0}, f.Flows())
+
+	// 负费用: 走 0->1->3 比 0->2->3 便宜
+	neg := NewFlowNetwork(4)
+	neg.AddEdge(0, 1, 1, 2)
+	neg.AddEdge(1, 3, 1, -5)
+	neg.AddEdge(0, 2, 1, 0)
+	neg.AddEdge(2, 3, 1, 0)
+	f = NewMinCostFlow(neg, 0, 3, 1)
+	assert.Equal(t, -3, f.Cost())
+	assert.Equal(t, []int{1, 1, 0, 0}, f.Flows())
+
+	cycle := NewFlowNetwork(3)
+	cycle.AddEdge(0, 1, 1, 0)
+	cycle.AddEdge(1, 2, 1, -1)
+	cycle.AddEdge(2, 1, 1, -1)
+	assert.Panics(t, func() { NewMinCostFlow(cycle, 0, 2, -1) })
+}
+
+func TestMinCostFlowRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		n := 8
+		net := NewFlowNetwork(n)
+		perm := r.Perm(n)
+		for j := 0; j < 25; j++ {
+			v, w := r.Intn(n), r.Intn(n)
+			if v == w {
+				continue
+			}
+			cost := r.Intn(10)
+			// 一部分测试带负费用的边, 只加在顺着排列的方向上, 保证没有负费用的环
+			if i%2 == 1 && perm[v] < perm[w] {
+				cost -= 5
+			} else if i%2 == 1 {
+				continue
+			}
+			net.AddEdge(v, w, r.Intn(5), cost)
+		}
+		s, tt := 0, n-1
+		if i%2 == 1 {
+			s, tt = 0, 0
+			for v := range perm {
+				if perm[v] == 0 {
+					s = v
+				} else if perm[v] == n-1 {
+					tt = v
+				}
+			}
+		}
+		for _, limit := range []int{-1, 3} {
+			f := NewMinCostFlow(net, s, tt, limit)
+			flow, cost := bruteMinCostFlow(net, s, tt, limit)
+			assert.Equal(t, flow, f.Flow())
+			assert.Equal(t, cost, f.Cost())
+			assertFlow(t, net, s, tt, f)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// 生成各种结构的图, 用于测试和基准测试
+//
+// 确定结构的图 (完全图、路径、环、星形、轮形、网格、二叉树) 直接按参数生成,
+// 随机图都需要传入 seed, 相同的参数和 seed 总是生成相同的图
+package generate
+
+import (
+	"github.com/cc14514/go-cookiekit/graph"
+)
+
+// 完全图 K(n), 任意两个顶点之间都有边
+func Complete(n int) *graph.Graph {
+	g := graph.NewGraph(n)
+	for v := 0; v < n; v++ {
+		for w := v + 1; w < n; w++ {
+			g.AddEdge(v, w)
+		}
+	}
+	return g
+}
+
+// 完全有向图, 任意两个顶点之间都有两个方向的边
+func CompleteDigraph(n int) *graph.Digraph {
+	g := graph.NewDigraph(n)
+	for v := 0; v < n; v++ {
+		for w := 0; w < n; w++ {
+			if v != w {
+				g.AddEdge(v, w)
+			}
+		}
+	}
+	return g
+}
+
+// 路径 0-1-2-...-(n-1)
+func Path(n int) *graph.Graph {
+	g := graph.NewGraph(n)
+	for v := 0; v+1 < n; v++ {
+		g.AddEdge(v, v+1)
+	}
+	return g
+}
+
+// 有向路径 0->1->2->...->(n-1)
+func DirectedPath(n int) *graph.Digraph {
+	g := graph.NewDigraph(n)
+	for v := 0; v+1 < n; v++ {
+		g.AddEdge(v, v+1)
+	}
+	return g
+}
+
+// 环 0-1-...-(n-1)-0, n 至少为 3
+func Cycle(n int) *graph.Graph {
+	if n < 3 {
+		panic("cycle needs at least 3 vertices")
+	}
+	g := Path(n)
+	g.AddEdge(n-1, 0)
+	return g
+}
+
+// 有向环 0->1->...->(n-1)->0, n 至少为 2
+func DirectedCycle(n int) *graph.Digraph {
+	if n < 2 {
+		panic("directed cycle needs at least 2 vertices")
+	}
+	g := DirectedPath(n)
+	g.AddEdge(n-1, 0)
+	return g
+}
+
+// 星形图, 中心 0 和其余 n-1 个顶点相连
+func Star(n int) *graph.Graph {
+	g := graph.NewGraph(n)
+	for v := 1; v < n; v++ {
+		g.AddEdge(0, v)
+	}
+	return g
+}
+
+// 轮形图, 中心 0 和环 1-2-...-(n-1)-1 上的每个顶点相连, n 至少为 4
+func Wheel(n int) *graph.Graph {
+	if n < 4 {
+		panic("wheel needs at least 4 vertices")
+	}
+	g := Star(n)
+	for v := 1; v+1 < n; v++ {
+		g.AddEdge(v, v+1)
+	}
+	g.AddEdge(n-1, 1)
+	return g
+}
+
+// rows x cols 的网格, 顶点 (r, c) 的编号为 r*cols+c
+func Grid(rows, cols int) *graph.Graph {
+	g := graph.NewGraph(rows * cols)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			if c+1 < cols {
+				g.AddEdge(r*cols+c, r*cols+c+1)
+			}
+			if r+1 < rows {
+				g.AddEdge(r*cols+c, (r+1)*cols+c)
+			}
+		}
+	}
+	return g
+}
+
+// rows x cols 的环面, 即首尾相接的网格, 行列数都至少为 3
+func Torus(rows, cols int) *graph.Graph {
+	if rows < 3 || cols < 3 {
+		panic("torus needs at least 3 rows and 3 columns")
+	}
+	g := graph.NewGraph(rows * cols)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			g.AddEdge(r*cols+c, r*cols+(c+1)%cols)
+			g.AddEdge(r*cols+c, (r+1)%rows*cols+c)
+		}
+	}
+	return g
+}
+
+// n 个顶点的完全二叉树, 顶点 v 的子节点为 2v+1 和 2v+2
+func BinaryTree(n int) *graph.Graph {
+	g := graph.NewGraph(n)
+	for v := 1; v < n; v++ {
+		g.AddEdge((v-1)/2, v)
+	}
+	return g
+}
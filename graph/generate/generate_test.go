@@ -0,0 +1,123 @@
+package generate
+
+import (
+	"github.com/cc14514/go-cookiekit/graph"
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"testing"
+)
+
+// 排好序的邻接表, 用来比较两个图是否完全相同
+func edges(g graph.SimpleGraph) [][]int {
+	r := make([][]int, g.V())
+	for v := 0; v < g.V(); v++ {
+		r[v] = g.Adj(v)
+		sort.Ints(r[v])
+	}
+	return r
+}
+
+func degrees(g graph.SimpleGraph) []int {
+	r := make([]int, g.V())
+	for v := range r {
+		r[v] = len(g.Adj(v))
+	}
+	return r
+}
+
+func TestDeterministic(t *testing.T) {
+	assert.Equal(t, 45, Complete(10).E())
+	assert.Equal(t, 90, CompleteDigraph(10).E())
+	assert.Equal(t, 9, Path(10).E())
+	assert.Equal(t, 10, Cycle(10).E())
+	assert.Equal(t, 10, DirectedCycle(10).E())
+	assert.Equal(t, 9, Star(10).E())
+	assert.Equal(t, 18, Wheel(10).E())
+	assert.Equal(t, 3*3+2*4, Grid(3, 4).E())
+	assert.Equal(t, 2*3*4, Torus(3, 4).E())
+	assert.Equal(t, 9, BinaryTree(10).E())
+	assert.Equal(t, []int{1, 9}, edges(BinaryTree(10))[4])
+
+	assert.True(t, graph.NewCycle(Cycle(10)).HasCycle())
+	assert.False(t, graph.NewCycle(BinaryTree(100)).HasCycle())
+	assert.True(t, graph.NewDirectedCycle(DirectedCycle(10)).HasCycle())
+	assert.False(t, graph.NewDirectedCycle(DirectedPath(10)).HasCycle())
+	for _, d := range degrees(Torus(5, 6)) {
+		assert.Equal(t, 4, d)
+	}
+}
+
+func TestGNP(t *testing.T) {
+	g := GNP(1000, 0.01, 1)
+	assert.Equal(t, edges(g), edges(GNP(1000, 0.01, 1)))
+	assert.NotEqual(t, edges(g), edges(GNP(1000, 0.01, 2)))
+	// 期望边数 4995, 偏差在几个标准差以内
+	assert.InDelta(t, 4995, g.E(), 400)
+	assert.Equal(t, 0, GNP(100, 0, 1).E())
+	assert.Equal(t, 4950, GNP(100, 1, 1).E())
+
+	d := DirectedGNP(1000, 0.01, 1)
+	assert.InDelta(t, 9990, d.E(), 600)
+	assert.Equal(t, 9900, DirectedGNP(100, 1, 1).E())
+}
+
+func TestGNM(t *testing.T) {
+	assert.Equal(t, 3000, GNM(1000, 3000, 1).E())
+	assert.Equal(t, 4000, GNM(100, 4000, 1).E())
+	assert.Equal(t, edges(GNM(100, 300, 7)), edges(GNM(100, 300, 7)))
+	assert.Equal(t, 5000, DirectedGNM(100, 5000, 1).E())
+	assert.Panics(t, func() { GNM(10, 46, 1) })
+}
+
+func TestBarabasiAlbert(t *testing.T) {
+	g := BarabasiAlbert(1000, 3, 1)
+	assert.Equal(t, 3*2+(1000-4)*3, g.E())
+	for _, d := range degrees(g) {
+		assert.True(t, d >= 3)
+	}
+	assert.Equal(t, 1, graph.NewCC(g).Count())
+}
+
+func TestWattsStrogatz(t *testing.T) {
+	g := WattsStrogatz(1000, 6, 0.1, 1)
+	assert.Equal(t, 3000, g.E())
+	assert.Equal(t, edges(g), edges(WattsStrogatz(1000, 6, 0.1, 1)))
+	ring := WattsStrogatz(100, 4, 0, 1)
+	for _, d := range degrees(ring) {
+		assert.Equal(t, 4, d)
+	}
+}
+
+func TestRandomDAG(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		assert.False(t, graph.NewDirectedCycle(RandomDAG(200, 0.05, seed)).HasCycle())
+	}
+}
+
+func TestRandomBipartite(t *testing.T) {
+	g := RandomBipartite(50, 80, 0.1, 1)
+	assert.Equal(t, 130, g.V())
+	assert.True(t, graph.NewTowColor(g).IsBipartite())
+	for v := 0; v < 50; v++ {
+		for _, w := range g.Adj(v) {
+			assert.True(t, w >= 50)
+		}
+	}
+}
+
+func TestRandomRegular(t *testing.T) {
+	for _, d := range []int{0, 1, 3, 4, 9} {
+		g := RandomRegular(20, d, int64(d))
+		assert.Equal(t, 20*d/2, g.E())
+		for _, deg := range degrees(g) {
+			assert.Equal(t, d, deg)
+		}
+	}
+	assert.Panics(t, func() { RandomRegular(5, 3, 1) })
+}
+
+func BenchmarkGNP(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		GNP(100000, 0.0001, int64(i))
+	}
+}
@@ -0,0 +1,325 @@
+package generate
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/cc14514/go-cookiekit/graph"
+)
+
+// 以概率 p 跳跃式地枚举 [0, total) 中被选中的下标 (Batagelj-Brandes),
+// 稀疏图的时间复杂度与选中的数量成正比, 而不是与 total 成正比
+func sample(r *rand.Rand, total int, p float64, f func(i int)) {
+	if p <= 0 {
+		return
+	}
+	if p >= 1 {
+		for i := 0; i < total; i++ {
+			f(i)
+		}
+		return
+	}
+	lp := math.Log(1 - p)
+	for i := -1; ; {
+		skip := math.Log(1-r.Float64()) / lp
+		if skip >= float64(total-i-1) {
+			return
+		}
+		i += 1 + int(skip)
+		f(i)
+	}
+}
+
+// 把 [0, n*(n-1)/2) 中的下标映射到无向边 v < w
+func pairOf(i int) (v, w int) {
+	w = int((1 + math.Sqrt(float64(1+8*i))) / 2)
+	for w*(w-1)/2 > i {
+		w--
+	}
+	for (w+1)*w/2 <= i {
+		w++
+	}
+	return i - w*(w-1)/2, w
+}
+
+// Erdős–Rényi 随机图 G(n, p), 每条边以概率 p 独立出现
+func GNP(n int, p float64, seed int64) *graph.Graph {
+	r := rand.New(rand.NewSource(seed))
+	g := graph.NewGraph(n)
+	sample(r, n*(n-1)/2, p, func(i int) {
+		g.AddEdge(pairOf(i))
+	})
+	return g
+}
+
+// 有向随机图, 每条有向边 v->w (v != w) 以概率 p 独立出现
+func DirectedGNP(n int, p float64, seed int64) *graph.Digraph {
+	r := rand.New(rand.NewSource(seed))
+	g := graph.NewDigraph(n)
+	if n < 2 {
+		return g
+	}
+	sample(r, n*(n-1), p, func(i int) {
+		v, w := i/(n-1), i%(n-1)
+		if w >= v {
+			w++
+		}
+		g.AddEdge(v, w)
+	})
+	return g
+}
+
+// 从 total 个下标中均匀地选出 m 个不同的下标
+// m 超过一半时改为选出不要的 total-m 个
+func choose(r *rand.Rand, total, m int, f func(i int)) {
+	if m < 0 || m > total {
+		panic("too many edges")
+	}
+	invert := m > total/2
+	k := m
+	if invert {
+		k = total - m
+	}
+	picked := make(map[int]bool, k)
+	for len(picked) < k {
+		picked[r.Intn(total)] = true
+	}
+	if !invert {
+		// 按下标排序后输出, 保证相同的 seed 生成的图完全一致
+		keys := make([]int, 0, k)
+		for i := range picked {
+			keys = append(keys, i)
+		}
+		sort.Ints(keys)
+		for _, i := range keys {
+			f(i)
+		}
+		return
+	}
+	for i := 0; i < total; i++ {
+		if !picked[i] {
+			f(i)
+		}
+	}
+}
+
+// Erdős–Rényi 随机图 G(n, m), 在所有 m 条边的图中均匀选择一个
+func GNM(n, m int, seed int64) *graph.Graph {
+	r := rand.New(rand.NewSource(seed))
+	g := graph.NewGraph(n)
+	choose(r, n*(n-1)/2, m, func(i int) {
+		g.AddEdge(pairOf(i))
+	})
+	return g
+}
+
+// 有向随机图, 在所有 m 条有向边的图中均匀选择一个
+func DirectedGNM(n, m int, seed int64) *graph.Digraph {
+	r := rand.New(rand.NewSource(seed))
+	g := graph.NewDigraph(n)
+	if n < 2 {
+		choose(r, 0, m, func(int) {})
+		return g
+	}
+	choose(r, n*(n-1), m, func(i int) {
+		v, w := i/(n-1), i%(n-1)
+		if w >= v {
+			w++
+		}
+		g.AddEdge(v, w)
+	})
+	return g
+}
+
+// Barabási–Albert 优先连接模型
+// 前 m+1 个顶点构成完全图, 之后每个新顶点按度数成比例地连接 m 个不同的旧顶点
+func BarabasiAlbert(n, m int, seed int64) *graph.Graph {
+	if m < 1 || n <= m {
+		panic("barabasi-albert needs 1 <= m < n")
+	}
+	r := rand.New(rand.NewSource(seed))
+	g := graph.NewGraph(n)
+	// 每条边的两个端点都放进 ends, 从中均匀选取就是按度数成比例选取
+	ends := make([]int, 0, 2*n*m)
+	for v := 0; v <= m; v++ {
+		for w := v + 1; w <= m; w++ {
+			g.AddEdge(v, w)
+			ends = append(ends, v, w)
+		}
+	}
+	targets := make([]int, 0, m)
+	for v := m + 1; v < n; v++ {
+		targets = targets[:0]
+		for len(targets) < m {
+			w := ends[r.Intn(len(ends))]
+			if !contains(targets, w) {
+				targets = append(targets, w)
+			}
+		}
+		for _, w := range targets {
+			g.AddEdge(v, w)
+			ends = append(ends, v, w)
+		}
+	}
+	return g
+}
+
+func contains(s []int, v int) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Watts–Strogatz 小世界模型
+// 从每个顶点连接左右各 k/2 个邻居的环开始, 每条边以概率 beta 把远端重连到随机顶点
+func WattsStrogatz(n, k int, beta float64, seed int64) *graph.Graph {
+	if k%2 != 0 || k < 2 || k >= n {
+		panic("watts-strogatz needs an even k with 2 <= k < n")
+	}
+	r := rand.New(rand.NewSource(seed))
+	adj := make([]map[int]bool, n)
+	for v := range adj {
+		adj[v] = make(map[int]bool)
+	}
+	link := func(v, w int, on bool) {
+		if on {
+			adj[v][w], adj[w][v] = true, true
+		} else {
+			delete(adj[v], w)
+			delete(adj[w], v)
+		}
+	}
+	for v := 0; v < n; v++ {
+		for j := 1; j <= k/2; j++ {
+			link(v, (v+j)%n, true)
+		}
+	}
+	for j := 1; j <= k/2; j++ {
+		for v := 0; v < n; v++ {
+			if r.Float64() >= beta {
+				continue
+			}
+			w := (v + j) % n
+			if len(adj[v]) >= n-1 { // v 已经和所有顶点相连, 无法重连
+				continue
+			}
+			x := r.Intn(n)
+			for x == v || adj[v][x] {
+				x = r.Intn(n)
+			}
+			link(v, w, false)
+			link(v, x, true)
+		}
+	}
+	g := graph.NewGraph(n)
+	for v := 0; v < n; v++ {
+		// 按顺序加边, 保证相同的 seed 生成的图完全一致
+		for w := v + 1; w < n; w++ {
+			if adj[v][w] {
+				g.AddEdge(v, w)
+			}
+		}
+	}
+	return g
+}
+
+// 随机有向无环图
+// 先随机排列顶点, 每对顶点以概率 p 连一条从排列靠前的顶点指向靠后的顶点的边
+func RandomDAG(n int, p float64, seed int64) *graph.Digraph {
+	r := rand.New(rand.NewSource(seed))
+	perm := r.Perm(n)
+	g := graph.NewDigraph(n)
+	sample(r, n*(n-1)/2, p, func(i int) {
+		v, w := pairOf(i)
+		g.AddEdge(perm[v], perm[w])
+	})
+	return g
+}
+
+// 随机二分图, 左侧顶点为 0..n1-1, 右侧顶点为 n1..n1+n2-1
+// 每对左右顶点之间以概率 p 独立连边
+func RandomBipartite(n1, n2 int, p float64, seed int64) *graph.Graph {
+	r := rand.New(rand.NewSource(seed))
+	g := graph.NewGraph(n1 + n2)
+	if n2 == 0 {
+		return g
+	}
+	sample(r, n1*n2, p, func(i int) {
+		g.AddEdge(i/n2, n1+i%n2)
+	})
+	return g
+}
+
+// 随机 d 正则图, 每个顶点的度数都是 d
+// 使用 Steger–Wormald 配对算法, 配对陷入死局时重新开始
+func RandomRegular(n, d int, seed int64) *graph.Graph {
+	if d < 0 || d >= n || n*d%2 != 0 {
+		panic("random regular graph needs 0 <= d < n and n*d even")
+	}
+	r := rand.New(rand.NewSource(seed))
+	for {
+		if edges := pairing(r, n, d); edges != nil {
+			g := graph.NewGraph(n)
+			for i := 0; i < len(edges); i += 2 {
+				g.AddEdge(edges[i], edges[i+1])
+			}
+			return g
+		}
+	}
+}
+
+// 一次配对尝试, 失败时返回 nil
+func pairing(r *rand.Rand, n, d int) []int {
+	points := make([]int, 0, n*d)
+	for v := 0; v < n; v++ {
+		for i := 0; i < d; i++ {
+			points = append(points, v)
+		}
+	}
+	adj := make([]map[int]bool, n)
+	for v := range adj {
+		adj[v] = make(map[int]bool)
+	}
+	edges := make([]int, 0, n*d)
+	for len(points) > 0 {
+		found := false
+		for try := 0; try < 100 && !found; try++ {
+			i, j := r.Intn(len(points)), r.Intn(len(points))
+			v, w := points[i], points[j]
+			if v == w || adj[v][w] {
+				continue
+			}
+			found = true
+			adj[v][w], adj[w][v] = true, true
+			edges = append(edges, v, w)
+			// 先删除下标较大的点, 避免另一个点被挪走
+			if i < j {
+				i, j = j, i
+			}
+			points[i] = points[len(points)-1]
+			points = points[:len(points)-1]
+			points[j] = points[len(points)-1]
+			points = points[:len(points)-1]
+		}
+		if !found && !suitable(points, adj) {
+			return nil
+		}
+	}
+	return edges
+}
+
+// 剩余的点中是否还存在可以配对的两个点
+func suitable(points []int, adj []map[int]bool) bool {
+	for i, v := range points {
+		for _, w := range points[i+1:] {
+			if v != w && !adj[v][w] {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -49,6 +49,9 @@ func (self *Graph) Adj(v int) []int {
 		return nil
 	}
 	bag := self.adj[v]
+	if bag == nil { // 孤立顶点
+		return nil
+	}
 	r := make([]int, 0)
 	bag.Items(func(i interface{}) {
 		r = append(r, i.(int))
@@ -65,6 +68,9 @@ func (self *Graph) String() string {
 	buf.WriteString("\n")
 	filter := make(map[struct{ a, b interface{} }]bool)
 	for i, n := range self.adj {
+		if n == nil {
+			continue
+		}
 		n.Items(func(val interface{}) {
 			if !filter[struct{ a, b interface{} }{i, val}] {
 				filter[struct{ a, b interface{} }{val, i}] = true
@@ -166,3 +172,19 @@ func NewGraphByAdjacencyList(data string) (g *Graph) {
 	}
 	return
 }
+
+// 一次性取出所有顶点的邻接表
+// 需要反复访问邻接表的算法先调用它, 避免每次 Adj 都重新分配
+func adjacency(graph SimpleGraph) [][]int {
+	adj := make([][]int, graph.V())
+	for v := range adj {
+		adj[v] = graph.Adj(v)
+	}
+	return adj
+}
+
+// 有向图会在 Adj 中只返回出边, 无向图的每条边在两端都会出现
+func isDirected(graph SimpleGraph) bool {
+	_, ok := graph.(SimpleDigraph)
+	return ok
+}
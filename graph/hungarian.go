@@ -0,0 +1,201 @@
+package graph
+
+import (
+	"math"
+)
+
+// 匈牙利算法 (Kuhn–Munkres) 求解指派问题
+// 逐行加入, 维护行和列的势 u, v 使得 cost[i][j] - u[i] - v[j] >= 0, 每加入一行就沿约化代价为 0 的边
+// 找一条增广路, 找不到时调整势让新的边变紧, 时间复杂度 O(n^2 m), n <= m 为行数和列数
+type AssignmentImpl struct {
+	assignment []int
+	cost       float64
+}
+
+// cost 是 n 行 m 列的代价矩阵, n 和 m 可以不相等, 多出来的行或列不参与分配
+// maximize 为 true 时求代价之和最大的分配
+func NewHungarian(cost [][]float64, maximize bool) Assignment {
+	n := len(cost)
+	m := 0
+	if n > 0 {
+		m = len(cost[0])
+	}
+	a := &AssignmentImpl{assignment: make([]int, n)}
+	sign := 1.0
+	if maximize {
+		sign = -1
+	}
+	// 行比列多时转置, 保证行数不超过列数
+	at := func(i, j int) float64 { return sign * cost[i][j] }
+	rows, cols := n, m
+	if n > m {
+		at = func(i, j int) float64 { return sign * cost[j][i] }
+		rows, cols = m, n
+	}
+	col := hungarian(rows, cols, at)
+	for i := range a.assignment {
+		a.assignment[i] = -1
+	}
+	for i, j := range col {
+		if n > m {
+			i, j = j, i
+		}
+		a.assignment[i] = j
+		a.cost += cost[i][j]
+	}
+	return a
+}
+
+// 下标从 1 开始, 第 0 列是虚拟的列, p[j] 是分配到第 j 列的行, way[j] 是增广路上 j 的前一列
+// 返回每一行分配到的列, rows <= cols
+func hungarian(rows, cols int, cost func(i, j int) float64) []int {
+	u, v := make([]float64, rows+1), make([]float64, cols+1)
+	p, way := make([]int, cols+1), make([]int, cols+1)
+	minv := make([]float64, cols+1)
+	used := make([]bool, cols+1)
+	for i := 1; i <= rows; i++ {
+		p[0] = i
+		j0 := 0
+		for j := range minv {
+			minv[j], used[j] = math.Inf(1), false
+		}
+		for p[j0] != 0 {
+			used[j0] = true
+			i0, delta, j1 := p[j0], math.Inf(1), 0
+			for j := 1; j <= cols; j++ {
+				if used[j] {
+					continue
+				}
+				if cur := cost(i0-1, j-1) - u[i0] - v[j]; cur < minv[j] {
+					minv[j], way[j] = cur, j0
+				}
+				if minv[j] < delta {
+					delta, j1 = minv[j], j
+				}
+			}
+			for j := 0; j <= cols; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+		}
+		// 沿 way 把增广路上的分配依次后移
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+	col := make([]int, rows)
+	for j := 1; j <= cols; j++ {
+		if p[j] != 0 {
+			col[p[j]-1] = j - 1
+		}
+	}
+	return col
+}
+
+func (self *AssignmentImpl) Assignment() []int {
+	return self.assignment
+}
+
+func (self *AssignmentImpl) Cost() float64 {
+	return self.cost
+}
+
+type MatchingImpl struct {
+	mate   []int
+	size   int
+	weight float64
+}
+
+func newMatching(n int) *MatchingImpl {
+	m := &MatchingImpl{mate: make([]int, n)}
+	for v := range m.mate {
+		m.mate[v] = -1
+	}
+	return m
+}
+
+func (self *MatchingImpl) match(v, w int, weight float64) {
+	self.mate[v], self.mate[w] = w, v
+	self.size++
+	self.weight += weight
+}
+
+func (self *MatchingImpl) Mate(v int) int {
+	return self.mate[v]
+}
+
+func (self *MatchingImpl) Size() int {
+	return self.size
+}
+
+func (self *MatchingImpl) Weight() float64 {
+	return self.weight
+}
+
+// 加权二分图的最优匹配, 两侧由 TowColor 的染色确定, 不是二分图时 panic
+// 先让匹配的边数最多, 在此基础上求权重之和最小 (maximize 为 true 时最大) 的匹配
+// 没有边的格子用一个比所有权重的变化范围都大的代价 M 填充, 分配到这些格子的行视为没有匹配
+func NewBipartiteAssignment(graph WeightedGraph, maximize bool) Matching {
+	n := graph.V()
+	unweighted := NewGraph(n)
+	for _, e := range graph.Edges() {
+		unweighted.AddEdge(e.V, e.W)
+	}
+	tc := NewTowColor(unweighted).(*TowColorImpl)
+	if !tc.IsBipartite() {
+		panic("not bipartite")
+	}
+	index := make([]int, n) // 顶点在所在一侧的编号
+	left, right := make([]int, 0), make([]int, 0)
+	for v := 0; v < n; v++ {
+		if tc.Color(v) {
+			index[v] = len(right)
+			right = append(right, v)
+		} else {
+			index[v] = len(left)
+			left = append(left, v)
+		}
+	}
+	sign := 1.0
+	if maximize {
+		sign = -1
+	}
+	big := 1.0
+	for _, e := range graph.Edges() {
+		big += 2 * math.Abs(e.Weight)
+	}
+	cost := make([][]float64, len(left))
+	has := make([][]bool, len(left))
+	for i := range cost {
+		cost[i] = make([]float64, len(right))
+		has[i] = make([]bool, len(right))
+		for j := range cost[i] {
+			cost[i][j] = big
+		}
+	}
+	for _, e := range graph.Edges() {
+		v, w := e.V, e.W
+		if tc.Color(v) {
+			v, w = w, v
+		}
+		i, j := index[v], index[w]
+		// 平行边只保留最好的一条
+		if c := sign * e.Weight; !has[i][j] || c < cost[i][j] {
+			cost[i][j], has[i][j] = c, true
+		}
+	}
+	m := newMatching(n)
+	for i, j := range NewHungarian(cost, false).Assignment() {
+		if j >= 0 && has[i][j] {
+			m.match(left[i], right[j], sign*cost[i][j])
+		}
+	}
+	return m
+}
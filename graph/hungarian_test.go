@@ -0,0 +1,177 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// 枚举所有分配, 行数不超过列数时每行分配一列, 否则每列分配一行
+func bruteAssignment(cost [][]float64, maximize bool) float64 {
+	n, m := len(cost), len(cost[0])
+	best := math.Inf(1)
+	if maximize {
+		best = math.Inf(-1)
+	}
+	used := make([]bool, m)
+	var dfs func(i, assigned int, sum float64)
+	dfs = func(i, assigned int, sum float64) {
+		if i == n {
+			if assigned == n || assigned == m {
+				if maximize {
+					best = math.Max(best, sum)
+				} else {
+					best = math.Min(best, sum)
+				}
+			}
+			return
+		}
+		if n > m {
+			dfs(i+1, assigned, sum) // 行比列多, 这一行可以不分配
+		}
+		for j := 0; j < m; j++ {
+			if !used[j] {
+				used[j] = true
+				dfs(i+1, assigned+1, sum+cost[i][j])
+				used[j] = false
+			}
+		}
+	}
+	dfs(0, 0, 0)
+	return best
+}
+
+func assertAssignment(t *testing.T, cost [][]float64, a Assignment) {
+	n, m := len(cost), len(cost[0])
+	used := make(map[int]bool)
+	sum := 0.0
+	count := 0
+	for i, j := range a.Assignment() {
+		if j < 0 {
+			continue
+		}
+		assert.False(t, used[j])
+		used[j] = true
+		sum += cost[i][j]
+		count++
+	}
+	if n < m {
+		assert.Equal(t, n, count)
+	} else {
+		assert.Equal(t, m, count)
+	}
+	assert.InDelta(t, sum, a.Cost(), 1e-9)
+}
+
+func TestHungarian(t *testing.T) {
+	cost := [][]float64{
+		{4, 1, 3},
+		{2, 0, 5},
+		{3, 2, 2},
+	}
+	a := NewHungarian(cost, false)
+	assert.Equal(t, []int{1, 0, 2}, a.Assignment())
+	assert.Equal(t, 5.0, a.Cost())
+	a = NewHungarian(cost, true)
+	assert.Equal(t, 11.0, a.Cost())
+
+	// 3 个任务, 2 台机器
+	tall := [][]float64{{1, 9}, {2, 3}, {8, 1}}
+	a = NewHungarian(tall, false)
+	assert.Equal(t, []int{0, -1, 1}, a.Assignment())
+	assert.Equal(t, 2.0, a.Cost())
+	assert.Equal(t, []int{}, NewHungarian([][]float64{}, false).Assignment())
+}
+
+func TestHungarianRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		n, m := 1+r.Intn(6), 1+r.Intn(6)
+		cost := make([][]float64, n)
+		for j := range cost {
+			cost[j] = make([]float64, m)
+			for k := range cost[j] {
+				cost[j][k] = float64(r.Intn(41) - 20)
+			}
+		}
+		for _, maximize := range []bool{false, true} {
+			a := NewHungarian(cost, maximize)
+			assertAssignment(t, cost, a)
+			assert.InDelta(t, bruteAssignment(cost, maximize), a.Cost(), 1e-9)
+		}
+	}
+}
+
+// 枚举二分图的所有匹配, 取边数最多的匹配中权重最优的
+func bruteBipartite(graph WeightedGraph, maximize bool) (int, float64) {
+	edges := graph.Edges()
+	bestSize, bestWeight := 0, 0.0
+	used := make([]bool, graph.V())
+	var dfs func(i, size int, weight float64)
+	dfs = func(i, size int, weight float64) {
+		if i == len(edges) {
+			better := weight < bestWeight
+			if maximize {
+				better = weight > bestWeight
+			}
+			if size > bestSize || size == bestSize && better {
+				bestSize, bestWeight = size, weight
+			}
+			return
+		}
+		dfs(i+1, size, weight)
+		if e := edges[i]; !used[e.V] && !used[e.W] {
+			used[e.V], used[e.W] = true, true
+			dfs(i+1, size+1, weight+e.Weight)
+			used[e.V], used[e.W] = false, false
+		}
+	}
+	dfs(0, 0, 0)
+	return bestSize, bestWeight
+}
+
+func assertMatching(t *testing.T, graph WeightedGraph, m Matching) {
+	size := 0
+	for v := 0; v < graph.V(); v++ {
+		w := m.Mate(v)
+		if w < 0 {
+			continue
+		}
+		assert.Equal(t, v, m.Mate(w))
+		if v < w {
+			size++
+			adjacent := false
+			for _, e := range graph.Adj(v) {
+				adjacent = adjacent || e.Other(v) == w
+			}
+			assert.True(t, adjacent, "%d-%d is not an edge", v, w)
+		}
+	}
+	assert.Equal(t, size, m.Size())
+}
+
+func TestBipartiteAssignment(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		n := 2 + r.Intn(8)
+		g := NewEdgeWeightedGraph(n)
+		for j := 0; j < n+r.Intn(n); j++ {
+			// 偶数和奇数顶点之间连边, 一定是二分图
+			v, w := 2*r.Intn((n+1)/2), 2*r.Intn(n/2)+1
+			g.AddEdge(Edge{v, w, float64(r.Intn(21) - 10)})
+		}
+		for _, maximize := range []bool{false, true} {
+			m := NewBipartiteAssignment(g, maximize)
+			assertMatching(t, g, m)
+			size, weight := bruteBipartite(g, maximize)
+			assert.Equal(t, size, m.Size())
+			assert.InDelta(t, weight, m.Weight(), 1e-9)
+		}
+	}
+	triangle := NewEdgeWeightedGraph(3)
+	triangle.AddEdge(Edge{0, 1, 1})
+	triangle.AddEdge(Edge{1, 2, 1})
+	triangle.AddEdge(Edge{2, 0, 1})
+	assert.Panics(t, func() { NewBipartiteAssignment(triangle, false) })
+}
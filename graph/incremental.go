@@ -0,0 +1,157 @@
+package graph
+
+import (
+	"sort"
+)
+
+// Pearce–Kelly 动态拓扑排序
+// 加入 v->w 时如果 v 已经排在 w 前面, 顺序不变; 否则只有位置落在 [pos(w), pos(v)] 之间的顶点受影响:
+// 从 w 向前搜索区间内它能到达的顶点 (碰到 v 就是环), 从 v 向后搜索区间内能到达 v 的顶点,
+// 然后把这两组顶点按原来的相对顺序重新放回它们占据的位置上, 前一组整体排在后一组之后
+type PearceKelly struct {
+	dig     *Digraph
+	out, in [][]int
+	pos     []int // pos[v] 是 v 在拓扑序中的位置
+	at      []int // at[i] 是位置 i 上的顶点
+	marked  []bool
+	edgeTo  []int
+}
+
+func NewIncrementalTopological(v int) IncrementalTopological {
+	pk := &PearceKelly{
+		dig: NewDigraph(v), out: make([][]int, v), in: make([][]int, v),
+		pos: make([]int, v), at: make([]int, v), marked: make([]bool, v), edgeTo: make([]int, v),
+	}
+	for i := 0; i < v; i++ {
+		pk.pos[i], pk.at[i] = i, i
+	}
+	return pk
+}
+
+// 用已有的 DAG 初始化; 有环时和 AddEdge 一样返回一个首尾相同的有向环, 第一个返回值为 nil
+func NewIncrementalTopologicalByDigraph(dig SimpleDigraph) (IncrementalTopological, []int) {
+	tl := NewKahnTopological(dig)
+	if !tl.IsDAG() {
+		return nil, tl.Cycle()
+	}
+	pk := NewIncrementalTopological(dig.V()).(*PearceKelly)
+	for i, v := range tl.Order() {
+		pk.pos[v], pk.at[i] = i, v
+	}
+	for v := 0; v < dig.V(); v++ {
+		for _, w := range dig.Adj(v) {
+			pk.insert(v, w)
+		}
+	}
+	return pk, nil
+}
+
+func (self *PearceKelly) insert(v, w int) {
+	self.dig.AddEdge(v, w)
+	self.out[v] = append(self.out[v], w)
+	self.in[w] = append(self.in[w], v)
+}
+
+func (self *PearceKelly) AddEdge(v, w int) []int {
+	if v == w {
+		return []int{v, v}
+	}
+	if b := self.dig.adj[v]; b != nil && b.Count(w) > 0 {
+		return nil
+	}
+	lb, ub := self.pos[w], self.pos[v]
+	if lb > ub {
+		self.insert(v, w)
+		return nil
+	}
+	forward, found := self.search(w, self.out, func(x int) bool { return self.pos[x] <= ub }, v)
+	self.unmark(forward)
+	if found {
+		// edgeTo 记录了 w 到 v 的路径, 加上新边 v->w 就是环
+		path := make([]int, 0)
+		for x := v; x != w; x = self.edgeTo[x] {
+			path = append(path, x)
+		}
+		path = append(path, w)
+		cycle := []int{v}
+		for i := len(path) - 1; i >= 0; i-- {
+			cycle = append(cycle, path[i])
+		}
+		return cycle
+	}
+	// 没有环时两组顶点不相交: 否则那个顶点既能从 w 到达又能到达 v
+	backward, _ := self.search(v, self.in, func(x int) bool { return self.pos[x] > lb }, -1)
+	self.unmark(backward)
+	self.reorder(backward, forward)
+	self.insert(v, w)
+	return nil
+}
+
+// 从 s 出发沿 adj 迭代深度优先搜索满足 in 的顶点, 遇到 target 时停止并返回 true
+func (self *PearceKelly) search(s int, adj [][]int, in func(int) bool, target int) ([]int, bool) {
+	reached := []int{s}
+	self.marked[s] = true
+	sk := []*dfsFrame{{v: s, adj: adj[s]}}
+	for len(sk) > 0 {
+		f := sk[len(sk)-1]
+		x, ok := f.next()
+		if !ok {
+			sk = sk[:len(sk)-1]
+			continue
+		}
+		if self.marked[x] || !in(x) {
+			continue
+		}
+		self.marked[x] = true
+		self.edgeTo[x] = f.v
+		reached = append(reached, x)
+		if x == target {
+			return reached, true
+		}
+		sk = append(sk, &dfsFrame{v: x, adj: adj[x]})
+	}
+	return reached, false
+}
+
+func (self *PearceKelly) unmark(vs []int) {
+	for _, v := range vs {
+		self.marked[v] = false
+	}
+}
+
+// backward 中的顶点都要排在 forward 前面, 两组内部保持原来的相对顺序,
+// 用到的位置就是这些顶点原来占据的位置
+func (self *PearceKelly) reorder(backward, forward []int) {
+	byPos := func(vs []int) {
+		sort.Slice(vs, func(i, j int) bool { return self.pos[vs[i]] < self.pos[vs[j]] })
+	}
+	byPos(backward)
+	byPos(forward)
+	vs := append(backward, forward...)
+	slots := make([]int, len(vs))
+	for i, v := range vs {
+		slots[i] = self.pos[v]
+	}
+	sort.Ints(slots)
+	for i, v := range vs {
+		self.pos[v], self.at[slots[i]] = slots[i], v
+	}
+}
+
+func (self *PearceKelly) IsDAG() bool {
+	return true
+}
+
+func (self *PearceKelly) Order() []int {
+	order := make([]int, len(self.at))
+	copy(order, self.at)
+	return order
+}
+
+func (self *PearceKelly) Position(v int) int {
+	return self.pos[v]
+}
+
+func (self *PearceKelly) Digraph() *Digraph {
+	return self.dig
+}
@@ -0,0 +1,98 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func assertTopological(t *testing.T, it IncrementalTopological) {
+	order := it.Order()
+	for i, v := range order {
+		assert.Equal(t, i, it.Position(v))
+	}
+	dig := it.Digraph()
+	for v := 0; v < dig.V(); v++ {
+		for _, w := range dig.Adj(v) {
+			assert.True(t, it.Position(v) < it.Position(w), "%d -> %d", v, w)
+		}
+	}
+}
+
+func TestIncrementalTopological(t *testing.T) {
+	it := NewIncrementalTopological(4)
+	assert.Nil(t, it.AddEdge(3, 2))
+	assert.Nil(t, it.AddEdge(2, 1))
+	assert.Nil(t, it.AddEdge(1, 0))
+	assert.Equal(t, []int{3, 2, 1, 0}, it.Order())
+	assert.Nil(t, it.AddEdge(3, 2)) // 重复的边
+	assert.Equal(t, 3, it.Digraph().E())
+	assert.Equal(t, []int{0, 3, 2, 1, 0}, it.AddEdge(0, 3))
+	assert.Equal(t, []int{2, 2}, it.AddEdge(2, 2))
+	assert.Equal(t, 3, it.Digraph().E())
+	assertTopological(t, it)
+
+	it, cycle := NewIncrementalTopologicalByDigraph(dag)
+	assert.Nil(t, cycle)
+	assert.Equal(t, dag.E(), it.Digraph().E())
+	assertTopological(t, it)
+	assert.NotNil(t, it.AddEdge(12, 8))
+	assert.True(t, it.Position(1) > it.Position(7))
+	assert.Nil(t, it.AddEdge(1, 7))
+	assertTopological(t, it)
+	cyclic := NewDigraph(2)
+	cyclic.AddEdge(0, 1)
+	cyclic.AddEdge(1, 0)
+	it, cycle = NewIncrementalTopologicalByDigraph(cyclic)
+	assert.Nil(t, it)
+	assert.Equal(t, 3, len(cycle))
+	assert.Equal(t, cycle[0], cycle[2])
+}
+
+func TestIncrementalTopologicalRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		n := 40
+		it := NewIncrementalTopological(n)
+		rejected := 0
+		for j := 0; j < 200; j++ {
+			v, w := r.Intn(n), r.Intn(n)
+			before := it.Digraph().E()
+			cycle := it.AddEdge(v, w)
+			if cycle == nil {
+				continue
+			}
+			rejected++
+			assert.Equal(t, before, it.Digraph().E())
+			// 环由新边 v->w 和图中已有的 w 到 v 的路径组成
+			assert.Equal(t, v, cycle[0])
+			assert.Equal(t, v, cycle[len(cycle)-1])
+			if v != w {
+				assert.Equal(t, w, cycle[1])
+			}
+			for k := 1; k+1 < len(cycle); k++ {
+				assert.True(t, contains(it.Digraph().Adj(cycle[k]), cycle[k+1]))
+			}
+		}
+		assert.True(t, rejected > 0)
+		assert.False(t, NewDirectedCycle(it.Digraph()).HasCycle())
+		assertTopological(t, it)
+	}
+}
+
+func BenchmarkIncrementalTopological(b *testing.B) {
+	// 边都顺着一个隐藏的排列, 按随机顺序加入, 不会被拒绝
+	r := rand.New(rand.NewSource(1))
+	n := 10000
+	perm := r.Perm(n)
+	for i := 0; i < b.N; i++ {
+		it := NewIncrementalTopological(n)
+		for j := 0; j < 4*n; j++ {
+			v, w := r.Intn(n), r.Intn(n)
+			if perm[v] > perm[w] {
+				v, w = w, v
+			}
+			it.AddEdge(v, w)
+		}
+	}
+}
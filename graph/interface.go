@@ -39,6 +39,24 @@ type DirectedSearch interface {
 	GenSearch(graph SimpleDigraph, s int) DirectedSearch
 }
 
+// 多个起点的 Search API, PathTo 返回从其中某个起点出发的路径
+type MultiSearch interface {
+	search
+	GenMultiSearch(graph SimpleGraph, sources ...int) MultiSearch
+}
+
+// 有向图多个起点的 Search API
+type DirectedMultiSearch interface {
+	search
+	GenMultiSearch(graph SimpleDigraph, sources ...int) DirectedMultiSearch
+}
+
+// 广度优先搜索的附加结果
+type BFSPaths interface {
+	DistTo(v int) int   // 到最近起点的边数, 不可达返回 -1
+	SourceOf(v int) int // 到达 v 的起点, 不可达返回 -1
+}
+
 // 无向图连通分量
 type CC interface {
 	Connected(v, w int) bool // v 和 w 是连通的吗
@@ -46,6 +64,13 @@ type CC interface {
 	ID(v int) int            // v 所在的连通分量
 }
 
+// 有向图强连通分量
+type SCC interface {
+	StronglyConnected(v, w int) bool // v 和 w 互相可达吗
+	Count() int                      // 强连通分量数
+	ID(v int) int                    // v 所在的强连通分量, 按缩点图的逆拓扑序编号: 有边 v->w 时 ID(v) >= ID(w)
+}
+
 // 判断一个图是否存在环
 type Cycle interface {
 	HasCycle() bool
@@ -70,3 +95,156 @@ type Topological interface {
 	IsDAG() bool
 	Order() []int
 }
+
+// 按入度逐层剥离顶点的拓扑排序, 除了顺序还给出可以并发执行的分层
+type LayeredTopological interface {
+	Topological
+	Layers() [][]int // 第 i 层的顶点只依赖前面的层, 同一层内互不依赖; 不是 DAG 时为 nil
+	Layer(v int) int // v 所在的层, 即以 v 结尾的最长路径的边数; 不是 DAG 时为 -1
+	Cycle() []int    // 不是 DAG 时的一个有向环, 首尾是同一个顶点, 否则为 nil
+}
+
+// 逐条加边时动态维护的拓扑序, 图始终是 DAG
+type IncrementalTopological interface {
+	Topological
+	AddEdge(v, w int) []int // 加入 v->w, 会形成环时拒绝加入并返回这个环 (首尾是同一个顶点), 否则返回 nil
+	Position(v int) int     // v 在当前拓扑序中的位置
+	Digraph() *Digraph      // 已经加入的边组成的图
+}
+
+// 遍历回调的返回值, 控制遍历如何继续
+type VisitResult int
+
+const (
+	VisitContinue VisitResult = iota // 继续遍历
+	VisitPrune                       // 剪枝: 不展开当前顶点, 或不沿当前树边前进
+	VisitStop                        // 立即终止整个遍历
+)
+
+// 图遍历的访问者, 由 DFSVisit / BFSVisit 等遍历引擎回调
+// 边 v-w 按深度优先遍历的定义分类:
+// 树边指向未发现的顶点, 回边指向祖先, 前向边指向已完成的后代, 横跨边指向其余已完成的顶点
+type Visitor interface {
+	DiscoverVertex(v int) VisitResult // 第一次访问到 v
+	FinishVertex(v int) VisitResult   // v 的邻接表全部处理完
+	TreeEdge(v, w int) VisitResult    // 树边 v-w
+	BackEdge(v, w int) VisitResult    // 回边 v-w
+	ForwardEdge(v, w int) VisitResult // 前向边 v-w, 只出现在有向图的深度优先遍历中
+	CrossEdge(v, w int) VisitResult   // 横跨边 v-w
+}
+
+// 加权无向图 接口
+type WeightedGraph interface {
+	V() int           //顶点数
+	E() int           //边数
+	AddEdge(e Edge)   //添加一条边
+	Adj(v int) []Edge //和 v 相连的边
+	Edges() []Edge    //所有的边, 每条边只出现一次
+	String() string   //对象的字符串表示
+}
+
+// 加权有向图 接口
+type WeightedDigraph interface {
+	V() int                   //顶点数
+	E() int                   //边数
+	AddEdge(e DirectedEdge)   //添加一条边
+	Adj(v int) []DirectedEdge //从 v 出发的边
+	Edges() []DirectedEdge    //所有的边
+	String() string           //对象的字符串表示
+}
+
+// 社区划分, 同一个社区的顶点视为连通
+type Communities interface {
+	CC                   // ID(v) 为 v 所在的社区, Count() 为社区数
+	Modularity() float64 // 划分的模块度
+}
+
+// 无向图的三角形计数和聚类系数
+type Triangles interface {
+	Count() int                 // 三角形总数
+	CountOf(v int) int          // 包含 v 的三角形数
+	Clustering(v int) float64   // v 的局部聚类系数
+	AverageClustering() float64 // 所有顶点局部聚类系数的平均值
+	Transitivity() float64      // 全局聚类系数: 3 * 三角形数 / 连通三元组数
+}
+
+// 无权图的距离指标, 距离为边数
+// 不连通的图中只考虑可达的顶点, 有向图按出边方向计算距离
+type Distances interface {
+	Eccentricity(v int) int // v 到可达顶点的最大距离
+	Diameter() int          // 最大的离心率
+	DiameterPath() []int    // 一条长度为直径的最短路径, 首尾即直径的两个端点
+	Radius() int            // 最小的离心率
+	Center() []int          // 离心率等于半径的顶点
+	Periphery() []int       // 离心率等于直径的顶点
+	Girth() int             // 最短环的长度, 无环时为 0
+	GirthCycle() []int      // 一个最短环, 首尾是同一个顶点, 无环时为 nil
+}
+
+// 无向图的 k-core 分解
+type Cores interface {
+	Core(v int) int              // v 的核数: v 所在的最大的 k-core 的 k
+	Degeneracy() int             // 退化度, 即最大的核数
+	Order() []int                // 退化序: 每个顶点排在它之后的邻居不超过 Degeneracy() 个
+	KCore(k int) (*Graph, []int) // k-core 子图, 顶点重新编号, 第二个返回值是新编号对应的原顶点
+}
+
+// 无向图着色, 相邻的顶点颜色不同
+type Coloring interface {
+	Color(v int) int  // v 的颜色, 从 0 开始编号
+	Count() int       // 用到的颜色数
+	Classes() [][]int // 每种颜色的顶点集合, 下标为颜色
+}
+
+// 有向图的支配树
+// 从根出发到 b 的每条路径都经过 a 时称 a 支配 b
+type Dominators interface {
+	Root() int
+	IDom(v int) int          // v 的直接支配者, 根和从根不可达的顶点返回 -1
+	Dominates(a, b int) bool // a 是否支配 b, 可达的顶点支配它自己
+	Children(v int) []int    // 支配树中 v 的子节点
+	Frontier(v int) []int    // v 的支配边界
+}
+
+// 最小费用流
+type MinCostFlow interface {
+	Flow() int           // 从源点到汇点的总流量
+	Cost() int           // 总费用, 每条边的流量乘以单位费用之和
+	EdgeFlow(id int) int // 流网络中编号为 id 的边上的流量
+	Flows() []int        // 按边的编号排列的流量
+}
+
+// 无向加权图的全局最小割, 把顶点分成两个非空的部分, 使得两部分之间的边权重之和最小
+type MinCut interface {
+	Weight() float64           // 割边的权重之和
+	Side(v int) int            // v 所在的部分, 0 或 1, 顶点 0 总在第 0 部分
+	Partition() ([]int, []int) // 两部分的顶点, 按编号排序
+	CutEdges() []Edge          // 两端在不同部分的边
+}
+
+// 有向图中以 root 为根的最小树形图: 权重之和最小的, 从根到每个顶点都恰好有一条路径的边集
+type Arborescence interface {
+	Root() int
+	Parent(v int) int      // 指向 v 的树边的起点, 根为 -1
+	Edges() []DirectedEdge // 树边, 按终点排序
+	Weight() float64       // 树边的权重之和
+}
+
+// 指派问题: 代价矩阵的每一行分配一个不同的列
+type Assignment interface {
+	Assignment() []int // 每一行分配到的列, 行比列多时有的行没有分配, 为 -1
+	Cost() float64     // 分配到的格子的代价之和
+}
+
+// 图的匹配: 没有公共顶点的边集
+type Matching interface {
+	Mate(v int) int  // 和 v 匹配的顶点, 没有匹配时为 -1
+	Size() int       // 匹配的边数
+	Weight() float64 // 匹配的边的权重之和, 无权图中为边数
+}
+
+// 最小生成树, 图不连通时是最小生成森林
+type MST interface {
+	Edges() []Edge   // 树边
+	Weight() float64 // 树边的权重之和
+}
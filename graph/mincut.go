@@ -0,0 +1,288 @@
+package graph
+
+import (
+	"math"
+	"math/bits"
+	"math/rand"
+	"sort"
+)
+
+type MinCutImpl struct {
+	weight float64
+	side   []int
+	cut    []Edge
+}
+
+// 根据一侧的顶点集合生成结果, 保证顶点 0 在第 0 部分
+func newMinCut(graph WeightedGraph, side []int) *MinCutImpl {
+	c := &MinCutImpl{side: side, cut: make([]Edge, 0)}
+	if side[0] == 1 {
+		for v := range side {
+			side[v] = 1 - side[v]
+		}
+	}
+	for _, e := range graph.Edges() {
+		if side[e.V] != side[e.W] {
+			c.weight += e.Weight
+			c.cut = append(c.cut, e)
+		}
+	}
+	return c
+}
+
+func (self *MinCutImpl) Weight() float64 {
+	return self.weight
+}
+
+func (self *MinCutImpl) Side(v int) int {
+	return self.side[v]
+}
+
+func (self *MinCutImpl) Partition() ([]int, []int) {
+	a, b := make([]int, 0), make([]int, 0)
+	for v, s := range self.side {
+		if s == 0 {
+			a = append(a, v)
+		} else {
+			b = append(b, v)
+		}
+	}
+	return a, b
+}
+
+func (self *MinCutImpl) CutEdges() []Edge {
+	return self.cut
+}
+
+// 检查输入: 至少两个顶点, 边的权重不能为负
+func checkMinCut(graph WeightedGraph) {
+	if graph.V() < 2 {
+		panic("error number")
+	}
+	for _, e := range graph.Edges() {
+		if e.Weight < 0 {
+			panic("negative weight")
+		}
+	}
+}
+
+// Stoer–Wagner 确定性全局最小割
+// 每个阶段从任一顶点开始, 不断加入与已加入集合连接最紧的顶点, 最后加入的两个顶点 s, t 之间的最小割
+// 就是 t 与其余顶点的割 (阶段割); 然后合并 s 和 t, V-1 个阶段的阶段割中最小的就是全局最小割
+// 用邻接矩阵实现, 时间复杂度 O(V^3), 空间 O(V^2), 适合几千个顶点以内的图
+func NewStoerWagner(graph WeightedGraph) MinCut {
+	checkMinCut(graph)
+	n := graph.V()
+	w := make([][]float64, n)
+	for v := range w {
+		w[v] = make([]float64, n)
+	}
+	for _, e := range graph.Edges() {
+		if e.V != e.W {
+			w[e.V][e.W] += e.Weight
+			w[e.W][e.V] += e.Weight
+		}
+	}
+	members := make([][]int, n) // 合并后的顶点代表的原图顶点
+	active := make([]int, n)
+	for v := range members {
+		members[v], active[v] = []int{v}, v
+	}
+	best, bestSide := math.Inf(1), []int(nil)
+	conn := make([]float64, n)
+	added := make([]bool, n)
+	for len(active) > 1 {
+		for _, v := range active {
+			conn[v], added[v] = 0, false
+		}
+		prev, last := -1, -1
+		for range active {
+			next := -1
+			for _, v := range active {
+				if !added[v] && (next < 0 || conn[v] > conn[next]) {
+					next = v
+				}
+			}
+			added[next] = true
+			prev, last = last, next
+			for _, v := range active {
+				if !added[v] {
+					conn[v] += w[next][v]
+				}
+			}
+		}
+		if conn[last] < best {
+			best, bestSide = conn[last], append([]int(nil), members[last]...)
+		}
+		// 把 last 合并到 prev
+		for _, v := range active {
+			w[prev][v] += w[last][v]
+			w[v][prev] = w[prev][v]
+		}
+		w[prev][prev] = 0
+		members[prev] = append(members[prev], members[last]...)
+		for i, v := range active {
+			if v == last {
+				active = append(active[:i], active[i+1:]...)
+				break
+			}
+		}
+	}
+	side := make([]int, n)
+	for _, v := range bestSide {
+		side[v] = 1
+	}
+	return newMinCut(graph, side)
+}
+
+// Karger–Stein 随机化全局最小割
+// 随机收缩边 (被选中的概率与权重成正比) 直到剩下 1 + V/√2 个顶点, 独立地做两次并分别递归,
+// 顶点数不超过 6 时直接枚举所有划分; 一次运行找到最小割的概率为 Ω(1/log V), 时间复杂度 O(V^2 log V)
+// trials 是独立运行的次数, 不大于 0 时取 ⌈log₂V⌉², 出错的概率约为 1/V
+// 常数比较大, 几百个顶点时一次运行就比 NewStoerWagner 的整个计算慢一个数量级
+func NewKargerStein(graph WeightedGraph, trials int, seed int64) MinCut {
+	checkMinCut(graph)
+	n := graph.V()
+	edges := make([]Edge, 0, graph.E())
+	for _, e := range graph.Edges() {
+		if e.V != e.W {
+			edges = append(edges, e)
+		}
+	}
+	// 不连通时任一连通分量和其余顶点之间的割都是 0
+	_, label := contract(n, edges, 1, nil)
+	side := make([]int, n)
+	disconnected := false
+	for v := range side {
+		if label[v] != label[0] {
+			side[v], disconnected = 1, true
+		}
+	}
+	if disconnected {
+		return newMinCut(graph, side)
+	}
+	if trials <= 0 {
+		trials = bits.Len(uint(n - 1))
+		trials *= trials
+	}
+	ks := &kargerStein{r: rand.New(rand.NewSource(seed)), n: n, best: math.Inf(1)}
+	for i := 0; i < trials; i++ {
+		ks.run(n, edges, nil)
+	}
+	return newMinCut(graph, ks.side)
+}
+
+type kargerStein struct {
+	r    *rand.Rand
+	n    int
+	best float64
+	side []int // 目前最好的划分
+}
+
+// maps 记录了从原图到当前图逐层收缩时顶点的对应关系
+func (self *kargerStein) run(n int, edges []Edge, maps [][]int) {
+	if n <= 6 {
+		self.brute(n, edges, maps)
+		return
+	}
+	t := 1 + int(math.Ceil(float64(n)/math.Sqrt2))
+	for i := 0; i < 2; i++ {
+		m, label := contract(n, edges, t, self.r)
+		self.run(t, m, append(maps[:len(maps):len(maps)], label))
+	}
+}
+
+// 枚举小图的所有划分, 顶点 n-1 固定在第 0 部分
+func (self *kargerStein) brute(n int, edges []Edge, maps [][]int) {
+	for mask := 1; mask < 1<<uint(n-1); mask++ {
+		cut := 0.0
+		for _, e := range edges {
+			if mask>>uint(e.V)&1 != mask>>uint(e.W)&1 {
+				cut += e.Weight
+			}
+		}
+		if cut >= self.best {
+			continue
+		}
+		self.best = cut
+		self.side = make([]int, self.n)
+		for v := range self.side {
+			x := v
+			for _, m := range maps {
+				x = m[x]
+			}
+			self.side[v] = mask >> uint(x) & 1
+		}
+	}
+}
+
+// 随机收缩到 t 个顶点: 每条边取一个参数为权重的指数分布随机数, 按从小到大的顺序用并查集合并端点,
+// 这和每次按权重随机选一条边收缩是等价的; r 为 nil 时按原来的顺序合并, 用来求连通分量
+// 返回收缩后的图 (合并了平行边, 去掉了自环) 和原顶点到新顶点的对应关系, 图必须是连通的
+func contract(n int, edges []Edge, t int, r *rand.Rand) ([]Edge, []int) {
+	order := make([]int, len(edges))
+	for i := range order {
+		order[i] = i
+	}
+	if r != nil {
+		key := make([]float64, len(edges))
+		for i, e := range edges {
+			key[i] = r.ExpFloat64() / e.Weight // 权重为 0 时是 +Inf, 排在最后
+		}
+		sort.Slice(order, func(i, j int) bool { return key[order[i]] < key[order[j]] })
+	}
+	parent := make([]int, n)
+	for v := range parent {
+		parent[v] = v
+	}
+	find := func(v int) int {
+		for parent[v] != v {
+			parent[v] = parent[parent[v]]
+			v = parent[v]
+		}
+		return v
+	}
+	count := n
+	for _, i := range order {
+		if count <= t {
+			break
+		}
+		if a, b := find(edges[i].V), find(edges[i].W); a != b {
+			parent[a] = b
+			count--
+		}
+	}
+	label := make([]int, n)
+	id := make(map[int]int)
+	for v := range label {
+		root := find(v)
+		if _, ok := id[root]; !ok {
+			id[root] = len(id)
+		}
+		label[v] = id[root]
+	}
+	type pair struct{ v, w int }
+	merged := make(map[pair]float64)
+	for _, e := range edges {
+		a, b := label[e.V], label[e.W]
+		if a == b {
+			continue
+		}
+		if a > b {
+			a, b = b, a
+		}
+		merged[pair{a, b}] += e.Weight
+	}
+	r2 := make([]Edge, 0, len(merged))
+	for p, weight := range merged {
+		r2 = append(r2, Edge{p.v, p.w, weight})
+	}
+	// map 的遍历顺序是随机的, 排序后结果才能由 seed 决定
+	sort.Slice(r2, func(i, j int) bool {
+		if r2[i].V != r2[j].V {
+			return r2[i].V < r2[j].V
+		}
+		return r2[i].W < r2[j].W
+	})
+	return r2, label
+}
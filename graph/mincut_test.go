@@ -0,0 +1,93 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// 枚举所有划分的最小割
+func bruteMinCut(graph WeightedGraph) float64 {
+	n := graph.V()
+	best := math.Inf(1)
+	for mask := 1; mask < 1<<uint(n-1); mask++ {
+		cut := 0.0
+		for _, e := range graph.Edges() {
+			if mask>>uint(e.V)&1 != mask>>uint(e.W)&1 {
+				cut += e.Weight
+			}
+		}
+		best = math.Min(best, cut)
+	}
+	return best
+}
+
+func assertMinCut(t *testing.T, graph WeightedGraph, c MinCut, weight float64) {
+	assert.InDelta(t, weight, c.Weight(), 1e-9)
+	a, b := c.Partition()
+	assert.True(t, len(a) > 0 && len(b) > 0)
+	assert.Equal(t, graph.V(), len(a)+len(b))
+	assert.Equal(t, 0, c.Side(0))
+	total := 0.0
+	for _, e := range c.CutEdges() {
+		assert.NotEqual(t, c.Side(e.V), c.Side(e.W))
+		total += e.Weight
+	}
+	assert.InDelta(t, weight, total, 1e-9)
+}
+
+func TestMinCut(t *testing.T) {
+	// Stoer–Wagner 论文中的例子, 最小割为 4: {2, 3, 6, 7} 和 {0, 1, 4, 5}
+	g := NewEdgeWeightedGraph(8)
+	for _, e := range []Edge{
+		{0, 1, 2}, {0, 4, 3}, {1, 2, 3}, {1, 4, 2}, {1, 5, 2}, {2, 3, 4}, {2, 6, 2},
+		{3, 6, 2}, {3, 7, 2}, {4, 5, 3}, {5, 6, 1}, {6, 7, 3},
+	} {
+		g.AddEdge(e)
+	}
+	for _, c := range []MinCut{NewStoerWagner(g), NewKargerStein(g, 0, 1)} {
+		assertMinCut(t, g, c, 4)
+		a, b := c.Partition()
+		assert.Equal(t, []int{0, 1, 4, 5}, a)
+		assert.Equal(t, []int{2, 3, 6, 7}, b)
+	}
+
+	// 不连通的图
+	d := NewEdgeWeightedGraph(4)
+	d.AddEdge(Edge{0, 1, 5})
+	d.AddEdge(Edge{2, 3, 5})
+	for _, c := range []MinCut{NewStoerWagner(d), NewKargerStein(d, 0, 1)} {
+		assertMinCut(t, d, c, 0)
+		assert.Equal(t, c.Side(0), c.Side(1))
+	}
+
+	assert.Panics(t, func() { NewStoerWagner(NewEdgeWeightedGraph(1)) })
+	d.AddEdge(Edge{1, 2, -1})
+	assert.Panics(t, func() { NewKargerStein(d, 0, 1) })
+}
+
+func TestMinCutRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 30; i++ {
+		n := 2 + r.Intn(12)
+		g := NewEdgeWeightedGraph(n)
+		for j := 0; j < 3*n; j++ {
+			g.AddEdge(Edge{r.Intn(n), r.Intn(n), float64(r.Intn(10))})
+		}
+		want := bruteMinCut(g)
+		assertMinCut(t, g, NewStoerWagner(g), want)
+		assertMinCut(t, g, NewKargerStein(g, 0, int64(i)), want)
+	}
+}
+
+func BenchmarkKargerStein(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	g := NewEdgeWeightedGraph(200)
+	for j := 0; j < 2000; j++ {
+		g.AddEdge(Edge{r.Intn(200), r.Intn(200), r.Float64()})
+	}
+	for i := 0; i < b.N; i++ {
+		NewKargerStein(g, 1, int64(i))
+	}
+}
@@ -0,0 +1,71 @@
+package graph
+
+import (
+	"container/heap"
+)
+
+// Prim 最小生成树 (延时实现)
+// 从一个顶点开始, 每次把连接树和非树顶点的权重最小的边加入树中, 优先队列里失效的边 (两端都在树中) 取出时跳过,
+// 对每个连通分量各做一次得到最小生成森林, 时间复杂度 O(E log E)
+type PrimMST struct {
+	edges  []Edge
+	weight float64
+}
+
+func NewPrimMST(graph WeightedGraph) MST {
+	n := graph.V()
+	mst := &PrimMST{edges: make([]Edge, 0, n)}
+	marked := make([]bool, n)
+	pq := &edgeHeap{}
+	visit := func(v int) {
+		marked[v] = true
+		for _, e := range graph.Adj(v) {
+			if !marked[e.Other(v)] {
+				heap.Push(pq, e)
+			}
+		}
+	}
+	for s := 0; s < n; s++ {
+		if marked[s] {
+			continue
+		}
+		visit(s)
+		for pq.Len() > 0 {
+			e := heap.Pop(pq).(Edge)
+			if marked[e.V] && marked[e.W] {
+				continue
+			}
+			mst.edges = append(mst.edges, e)
+			mst.weight += e.Weight
+			if !marked[e.V] {
+				visit(e.V)
+			}
+			if !marked[e.W] {
+				visit(e.W)
+			}
+		}
+	}
+	return mst
+}
+
+func (self *PrimMST) Edges() []Edge {
+	return self.edges
+}
+
+func (self *PrimMST) Weight() float64 {
+	return self.weight
+}
+
+// 按权重排序的边的最小堆
+type edgeHeap []Edge
+
+func (self edgeHeap) Len() int            { return len(self) }
+func (self edgeHeap) Less(i, j int) bool  { return self[i].Weight < self[j].Weight }
+func (self edgeHeap) Swap(i, j int)       { self[i], self[j] = self[j], self[i] }
+func (self *edgeHeap) Push(x interface{}) { *self = append(*self, x.(Edge)) }
+func (self *edgeHeap) Pop() interface{} {
+	old := *self
+	e := old[len(old)-1]
+	*self = old[:len(old)-1]
+	return e
+}
@@ -0,0 +1,103 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// 对照: Kruskal, 按权重从小到大加入不成环的边
+func kruskalWeight(graph WeightedGraph) (float64, int) {
+	edges := graph.Edges()
+	sort.Slice(edges, func(i, j int) bool { return edges[i].Weight < edges[j].Weight })
+	parent := make([]int, graph.V())
+	for v := range parent {
+		parent[v] = v
+	}
+	find := func(v int) int {
+		for parent[v] != v {
+			v = parent[v]
+		}
+		return v
+	}
+	weight, count := 0.0, 0
+	for _, e := range edges {
+		if a, b := find(e.V), find(e.W); a != b {
+			parent[a] = b
+			weight += e.Weight
+			count++
+		}
+	}
+	return weight, count
+}
+
+func TestPrimMST(t *testing.T) {
+	// Algorithms 第 4 版中的 tinyEWG, 最小生成树的权重为 1.81
+	g := NewEdgeWeightedGraph(8)
+	for _, e := range []Edge{
+		{4, 5, .35}, {4, 7, .37}, {5, 7, .28}, {0, 7, .16}, {1, 5, .32}, {0, 4, .38}, {2, 3, .17}, {1, 7, .19},
+		{0, 2, .26}, {1, 2, .36}, {1, 3, .29}, {2, 7, .34}, {6, 2, .40}, {3, 6, .52}, {6, 0, .58}, {6, 4, .93},
+	} {
+		g.AddEdge(e)
+	}
+	mst := NewPrimMST(g)
+	assert.InDelta(t, 1.81, mst.Weight(), 1e-9)
+	assert.Equal(t, 7, len(mst.Edges()))
+	t.Log(mst.Edges())
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		n := 1 + r.Intn(30)
+		g := NewEdgeWeightedGraph(n)
+		for j := r.Intn(3 * n); j > 0; j-- {
+			g.AddEdge(Edge{r.Intn(n), r.Intn(n), r.Float64()})
+		}
+		mst := NewPrimMST(g)
+		weight, count := kruskalWeight(g)
+		assert.InDelta(t, weight, mst.Weight(), 1e-9)
+		assert.Equal(t, count, len(mst.Edges()))
+		assertSpanningForest(t, g, mst)
+	}
+}
+
+// 树边无环, 并且连通分量和原图相同
+func assertSpanningForest(t *testing.T, g WeightedGraph, mst MST) {
+	forest := NewGraph(g.V())
+	unweighted := NewGraph(g.V())
+	for _, e := range mst.Edges() {
+		forest.AddEdge(e.V, e.W)
+	}
+	for _, e := range g.Edges() {
+		unweighted.AddEdge(e.V, e.W)
+	}
+	cc := NewCC(unweighted)
+	assert.Equal(t, g.V()-cc.Count(), len(mst.Edges()))
+	fc := NewCC(forest)
+	assert.Equal(t, cc.Count(), fc.Count())
+	for v := 0; v < g.V(); v++ {
+		assert.Equal(t, cc.Connected(0, v), fc.Connected(0, v))
+	}
+}
+
+func TestPrimMSTForest(t *testing.T) {
+	// 两个连通分量和一个孤立顶点, 自环和较重的平行边不会进入生成森林
+	g := NewEdgeWeightedGraph(6)
+	g.AddEdge(Edge{0, 1, 2})
+	g.AddEdge(Edge{0, 1, 1})
+	g.AddEdge(Edge{1, 2, 3})
+	g.AddEdge(Edge{2, 0, 4})
+	g.AddEdge(Edge{1, 1, 0})
+	g.AddEdge(Edge{3, 4, -1})
+	mst := NewPrimMST(g)
+	assert.Equal(t, 3, len(mst.Edges()))
+	assert.Equal(t, 3.0, mst.Weight())
+	assertSpanningForest(t, g, mst)
+	for _, e := range mst.Edges() {
+		assert.NotEqual(t, e.V, e.W)
+	}
+
+	empty := NewPrimMST(NewEdgeWeightedGraph(0))
+	assert.Empty(t, empty.Edges())
+	assert.Equal(t, 0.0, empty.Weight())
+}
@@ -0,0 +1,50 @@
+package graph
+
+import (
+	"github.com/cc14514/go-cookiekit/collections/bitset"
+	"sort"
+)
+
+// DAG 的传递约简: 可达关系不变的前提下边数最少的子图, 对 DAG 来说是唯一的
+// 按逆拓扑序计算每个顶点能到达的顶点集合, 处理 v 时把它的邻接顶点按拓扑序从前往后看,
+// w 已经能从排在前面的邻接顶点到达时 v->w 是冗余的, 否则保留并把 w 能到达的顶点并进来
+// 可达集合用位图表示, 时间复杂度 O(V*E/64), 空间 O(V^2/8) 字节
+// 返回约简后的图和按 (v, w) 排序的冗余边;
+// dig 有环时没有唯一的约简, 前两个返回值为 nil, cycle 是一个首尾相同的有向环
+func TransitiveReduction(dig SimpleDigraph) (reduced *Digraph, redundant [][2]int, cycle []int) {
+	tl := NewKahnTopological(dig)
+	if !tl.IsDAG() {
+		return nil, nil, tl.Cycle()
+	}
+	n := dig.V()
+	order := tl.Order()
+	pos := make([]int, n)
+	for i, v := range order {
+		pos[v] = i
+	}
+	adj := adjacency(dig)
+	reach := make([]*bitset.Bitset, n)
+	reduced = NewDigraph(n)
+	redundant = make([][2]int, 0)
+	for i := n - 1; i >= 0; i-- {
+		v := order[i]
+		reach[v] = bitset.New(n)
+		sort.Slice(adj[v], func(a, b int) bool { return pos[adj[v][a]] < pos[adj[v][b]] })
+		for _, w := range adj[v] {
+			if reach[v].Test(w) {
+				redundant = append(redundant, [2]int{v, w})
+				continue
+			}
+			reduced.AddEdge(v, w)
+			reach[v].Set(w)
+			reach[v].Union(reach[w])
+		}
+	}
+	sort.Slice(redundant, func(i, j int) bool {
+		if redundant[i][0] != redundant[j][0] {
+			return redundant[i][0] < redundant[j][0]
+		}
+		return redundant[i][1] < redundant[j][1]
+	})
+	return reduced, redundant, nil
+}
@@ -0,0 +1,78 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+// 传递闭包, 用来对照可达关系
+func closure(dig SimpleDigraph) [][]bool {
+	r := make([][]bool, dig.V())
+	for v := range r {
+		r[v] = make([]bool, dig.V())
+		s := new(DirectedSearchDFS).GenSearch(dig, v)
+		for w := range r[v] {
+			r[v][w] = w != v && s.Marked(w)
+		}
+	}
+	return r
+}
+
+func TestTransitiveReduction(t *testing.T) {
+	// 0->1->2->3 加上被蕴含的 0->2, 0->3, 1->3
+	d := NewDigraph(5)
+	d.AddEdge(0, 1)
+	d.AddEdge(1, 2)
+	d.AddEdge(2, 3)
+	d.AddEdge(0, 2)
+	d.AddEdge(0, 3)
+	d.AddEdge(1, 3)
+	d.AddEdge(4, 3)
+	reduced, redundant, cycle := TransitiveReduction(d)
+	assert.Nil(t, cycle)
+	assert.Equal(t, [][2]int{{0, 2}, {0, 3}, {1, 3}}, redundant)
+	assert.Equal(t, 4, reduced.E())
+	assert.Equal(t, []int{3}, reduced.Adj(4))
+
+	cyclic := NewDigraph(2)
+	cyclic.AddEdge(0, 1)
+	cyclic.AddEdge(1, 0)
+	reduced, redundant, cycle = TransitiveReduction(cyclic)
+	assert.Nil(t, reduced)
+	assert.Nil(t, redundant)
+	assert.Equal(t, 3, len(cycle))
+	assert.Equal(t, cycle[0], cycle[2])
+}
+
+func TestTransitiveReductionRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		n := 30
+		perm := r.Perm(n)
+		d := NewDigraph(n)
+		for j := 0; j < 120; j++ {
+			v, w := r.Intn(n), r.Intn(n)
+			if perm[v] < perm[w] {
+				d.AddEdge(v, w)
+			}
+		}
+		reduced, redundant, _ := TransitiveReduction(d)
+		assert.Equal(t, d.E(), reduced.E()+len(redundant))
+		assert.Equal(t, closure(d), closure(reduced))
+		// 最小性: 去掉约简图中的任何一条边都会改变可达关系
+		for v := 0; v < n; v++ {
+			for _, w := range reduced.Adj(v) {
+				s := NewDigraph(n)
+				for a := 0; a < n; a++ {
+					for _, b := range reduced.Adj(a) {
+						if a != v || b != w {
+							s.AddEdge(a, b)
+						}
+					}
+				}
+				assert.False(t, new(DirectedSearchDFS).GenSearch(s, v).Marked(w), "%d -> %d", v, w)
+			}
+		}
+	}
+}
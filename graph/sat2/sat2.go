@@ -0,0 +1,141 @@
+// 2-SAT 求解
+//
+// 每个子句是两个文字的析取 a ∨ b, 等价于两条蕴含 ¬a -> b 和 ¬b -> a;
+// 所有子句组成蕴含图, 变量 x 和 ¬x 落在同一个强连通分量里时无解,
+// 否则按缩点图的拓扑序, 取 x 和 ¬x 中排在后面的那个为真, 就得到一组满足所有子句的赋值
+package sat2
+
+import (
+	"github.com/cc14514/go-cookiekit/graph"
+	"strconv"
+)
+
+// 文字: 变量 x 或它的否定 ¬x, 编号 2x 和 2x+1 正好是蕴含图的顶点
+type Lit int
+
+// 变量 x 本身
+func Pos(x int) Lit {
+	return Lit(2 * x)
+}
+
+// 变量 x 的否定
+func Neg(x int) Lit {
+	return Lit(2*x + 1)
+}
+
+// 文字的否定
+func (self Lit) Not() Lit {
+	return self ^ 1
+}
+
+// 文字所属的变量
+func (self Lit) Var() int {
+	return int(self) >> 1
+}
+
+// 是不是否定形式 ¬x
+func (self Lit) Negative() bool {
+	return self&1 == 1
+}
+
+func (self Lit) String() string {
+	if self.Negative() {
+		return "¬" + strconv.Itoa(self.Var())
+	}
+	return strconv.Itoa(self.Var())
+}
+
+// 2-SAT 问题, 变量编号 0..n-1
+type Problem struct {
+	n       int
+	clauses [][2]Lit
+	dig     *graph.Digraph // 蕴含图
+}
+
+func New(n int) *Problem {
+	return &Problem{n: n, dig: graph.NewDigraph(2 * n)}
+}
+
+// 变量数
+func (self *Problem) N() int {
+	return self.n
+}
+
+// 已添加的子句
+func (self *Problem) Clauses() [][2]Lit {
+	return self.clauses
+}
+
+// 蕴含图, 顶点是文字
+func (self *Problem) Digraph() *graph.Digraph {
+	return self.dig
+}
+
+// 添加子句 a ∨ b
+func (self *Problem) AddClause(a, b Lit) {
+	if a < 0 || b < 0 || a.Var() >= self.n || b.Var() >= self.n {
+		panic("error number")
+	}
+	self.clauses = append(self.clauses, [2]Lit{a, b})
+	self.dig.AddEdge(int(a.Not()), int(b))
+	if a != b { // 单文字子句只需要一条边 ¬a -> a
+		self.dig.AddEdge(int(b.Not()), int(a))
+	}
+}
+
+// a 必须为真
+func (self *Problem) Must(a Lit) {
+	self.AddClause(a, a)
+}
+
+// a 为真时 b 必须为真: ¬a ∨ b
+func (self *Problem) Implies(a, b Lit) {
+	self.AddClause(a.Not(), b)
+}
+
+// a 和 b 不能同时为真: ¬a ∨ ¬b
+func (self *Problem) Exclude(a, b Lit) {
+	self.AddClause(a.Not(), b.Not())
+}
+
+// a 和 b 同真同假
+func (self *Problem) Equal(a, b Lit) {
+	self.Implies(a, b)
+	self.Implies(b, a)
+}
+
+// a 和 b 恰好一个为真
+func (self *Problem) Xor(a, b Lit) {
+	self.AddClause(a, b)
+	self.Exclude(a, b)
+}
+
+// 求解: 可满足时返回每个变量的取值和 -1,
+// 否则返回 nil 和一个冲突的变量 x, 蕴含图里 x 和 ¬x 互相可达
+func (self *Problem) Solve() ([]bool, int) {
+	scc := graph.NewSCC(self.dig)
+	assign := make([]bool, self.n)
+	for x := range assign {
+		p, q := scc.ID(int(Pos(x))), scc.ID(int(Neg(x)))
+		if p == q {
+			return nil, x
+		}
+		// 分量按逆拓扑序编号, 编号小的在拓扑序里靠后
+		assign[x] = p < q
+	}
+	return assign, -1
+}
+
+// 赋值是否满足所有子句
+func (self *Problem) Satisfied(assign []bool) bool {
+	for _, c := range self.clauses {
+		if !value(assign, c[0]) && !value(assign, c[1]) {
+			return false
+		}
+	}
+	return true
+}
+
+func value(assign []bool, a Lit) bool {
+	return assign[a.Var()] != a.Negative()
+}
@@ -0,0 +1,109 @@
+package sat2
+
+import (
+	"github.com/cc14514/go-cookiekit/graph"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestLit(t *testing.T) {
+	assert.Equal(t, Neg(3), Pos(3).Not())
+	assert.Equal(t, Pos(3), Neg(3).Not())
+	assert.Equal(t, 3, Neg(3).Var())
+	assert.True(t, Neg(3).Negative())
+	assert.False(t, Pos(3).Negative())
+	assert.Equal(t, "¬3", Neg(3).String())
+	assert.Equal(t, "0", Pos(0).String())
+}
+
+// 特性开关: 0 新界面, 1 旧界面, 2 暗色主题, 3 实验功能, 4 灰度发布
+func TestFeatureFlags(t *testing.T) {
+	p := New(5)
+	p.Xor(Pos(0), Pos(1))     // 新旧界面二选一
+	p.Implies(Pos(2), Pos(0)) // 暗色主题只有新界面支持
+	p.Implies(Pos(3), Pos(4)) // 实验功能需要灰度发布
+	p.Exclude(Pos(3), Pos(1)) // 实验功能和旧界面冲突
+	p.Must(Pos(2))
+	p.Must(Pos(3))
+	assign, conflict := p.Solve()
+	assert.Equal(t, -1, conflict)
+	assert.Equal(t, []bool{true, false, true, true, true}, assign)
+	assert.True(t, p.Satisfied(assign))
+	assert.Equal(t, 10, p.Digraph().V())
+
+	// 再要求旧界面就无解了
+	p.Must(Pos(1))
+	assign, conflict = p.Solve()
+	assert.Nil(t, assign)
+	assert.True(t, conflict >= 0)
+	assertConflict(t, p, conflict)
+	t.Log(conflict)
+}
+
+func TestEqual(t *testing.T) {
+	p := New(3)
+	p.Equal(Pos(0), Neg(1))
+	p.Equal(Pos(1), Pos(2))
+	p.Must(Pos(2))
+	assign, conflict := p.Solve()
+	assert.Equal(t, -1, conflict)
+	assert.Equal(t, []bool{false, true, true}, assign)
+	assert.Panics(t, func() { p.AddClause(Pos(3), Pos(0)) })
+
+	// 没有变量时总是可满足
+	assign, conflict = New(0).Solve()
+	assert.Empty(t, assign)
+	assert.Equal(t, -1, conflict)
+}
+
+// 冲突变量 x 和 ¬x 在蕴含图里互相可达
+func assertConflict(t *testing.T, p *Problem, x int) {
+	dig := p.Digraph()
+	pos := new(graph.DirectedSearchDFS).GenSearch(dig, int(Pos(x)))
+	neg := new(graph.DirectedSearchDFS).GenSearch(dig, int(Neg(x)))
+	assert.True(t, pos.Marked(int(Neg(x))))
+	assert.True(t, neg.Marked(int(Pos(x))))
+}
+
+// 枚举所有赋值
+func bruteSatisfiable(p *Problem) bool {
+	assign := make([]bool, p.N())
+	for mask := 0; mask < 1<<uint(p.N()); mask++ {
+		for x := range assign {
+			assign[x] = mask>>uint(x)&1 == 1
+		}
+		if p.Satisfied(assign) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSolveRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	lit := func(n int) Lit {
+		if r.Intn(2) == 0 {
+			return Pos(r.Intn(n))
+		}
+		return Neg(r.Intn(n))
+	}
+	sat := 0
+	for i := 0; i < 300; i++ {
+		n := 1 + r.Intn(10)
+		p := New(n)
+		for j := r.Intn(3 * n); j >= 0; j-- {
+			p.AddClause(lit(n), lit(n))
+		}
+		assign, conflict := p.Solve()
+		assert.Equal(t, bruteSatisfiable(p), assign != nil)
+		if assign != nil {
+			sat++
+			assert.Equal(t, -1, conflict)
+			assert.True(t, p.Satisfied(assign))
+		} else {
+			assertConflict(t, p, conflict)
+		}
+	}
+	t.Log("satisfiable", sat, "of 300")
+}
@@ -0,0 +1,44 @@
+package graph
+
+// Kosaraju 算法求强连通分量
+// 先按反向图的逆后序排列顶点, 再按这个顺序在原图上深度优先遍历, 每棵深度优先树就是一个强连通分量;
+// 反向图逆后序的第一个顶点在原图的汇点分量里, 所以分量依次按逆拓扑序被找到
+type KosarajuSCC struct {
+	count int
+	id    []int
+}
+
+func NewSCC(dig SimpleDigraph) SCC {
+	scc := &KosarajuSCC{id: make([]int, dig.V())}
+	for v := range scc.id {
+		scc.id[v] = -1
+	}
+	order := NewDFOrder(dig.Reverse()).ReversePost()
+	DirectedDFSVisit(dig, &VisitorFuncs{
+		OnDiscoverVertex: func(v int) VisitResult {
+			// 不是经树边到达的顶点是一棵新树的根
+			if scc.id[v] < 0 {
+				scc.id[v] = scc.count
+				scc.count++
+			}
+			return VisitContinue
+		},
+		OnTreeEdge: func(v, w int) VisitResult {
+			scc.id[w] = scc.id[v]
+			return VisitContinue
+		},
+	}, order...)
+	return scc
+}
+
+func (self *KosarajuSCC) StronglyConnected(v, w int) bool {
+	return self.id[v] == self.id[w]
+}
+
+func (self *KosarajuSCC) Count() int {
+	return self.count
+}
+
+func (self *KosarajuSCC) ID(v int) int {
+	return self.id[v]
+}
@@ -0,0 +1,54 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+// tinyDG: 5 个强连通分量 {1} {0 2 3 4 5} {9 10 11 12} {6 8} {7}
+func TestSCC(t *testing.T) {
+	g := NewDigraph(13)
+	for _, e := range [][2]int{{4, 2}, {2, 3}, {3, 2}, {6, 0}, {0, 1}, {2, 0}, {11, 12}, {12, 9}, {9, 10},
+		{9, 11}, {7, 9}, {10, 12}, {11, 4}, {4, 3}, {3, 5}, {6, 8}, {8, 6}, {5, 4}, {0, 5}, {6, 4}, {6, 9}, {7, 6}} {
+		g.AddEdge(e[0], e[1])
+	}
+	scc := NewSCC(g)
+	assert.Equal(t, 5, scc.Count())
+	assert.True(t, scc.StronglyConnected(0, 4))
+	assert.True(t, scc.StronglyConnected(9, 12))
+	assert.True(t, scc.StronglyConnected(6, 8))
+	assert.False(t, scc.StronglyConnected(1, 0))
+	assert.False(t, scc.StronglyConnected(7, 6))
+	// 逆拓扑序: 1 是汇点分量, 7 是源点分量
+	assert.Equal(t, 0, scc.ID(1))
+	assert.Equal(t, 4, scc.ID(7))
+	t.Log(scc.ID(0), scc.ID(9), scc.ID(6))
+}
+
+func TestSCCRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		n := 1 + r.Intn(20)
+		g := NewDigraph(n)
+		for j := r.Intn(2 * n); j > 0; j-- {
+			g.AddEdge(r.Intn(n), r.Intn(n))
+		}
+		scc := NewSCC(g)
+		reach := make([]DirectedSearch, n)
+		for v := range reach {
+			reach[v] = new(DirectedSearchDFS).GenSearch(g, v)
+		}
+		ids := map[int]bool{}
+		for v := 0; v < n; v++ {
+			ids[scc.ID(v)] = true
+			for w := 0; w < n; w++ {
+				assert.Equal(t, reach[v].Marked(w) && reach[w].Marked(v), scc.StronglyConnected(v, w))
+			}
+			for _, w := range g.Adj(v) {
+				assert.True(t, scc.ID(v) >= scc.ID(w))
+			}
+		}
+		assert.Equal(t, len(ids), scc.Count())
+	}
+}
@@ -0,0 +1,165 @@
+package graph
+
+import (
+	"github.com/cc14514/go-cookiekit/collections/prque"
+	"sort"
+)
+
+// Kahn 算法拓扑排序
+// 反复取出入度为 0 的顶点, 并把它指向的顶点的入度减一, 时间复杂度 O(V+E),
+// 最后还有顶点没被取出, 说明剩下的顶点里有环
+type KahnTopological struct {
+	order  []int
+	layers [][]int
+	layer  []int
+	cycle  []int
+}
+
+// 同一层的顶点按编号从小到大排列, 顺序就是各层依次拼接
+func NewKahnTopological(dig SimpleDigraph) LayeredTopological {
+	adj, indeg, t := newKahn(dig)
+	cur := make([]int, 0)
+	for v := range adj {
+		if indeg[v] == 0 {
+			cur = append(cur, v)
+		}
+	}
+	for len(cur) > 0 {
+		sort.Ints(cur)
+		t.order = append(t.order, cur...)
+		next := make([]int, 0)
+		for _, v := range cur {
+			for _, w := range adj[v] {
+				if indeg[w]--; indeg[w] == 0 {
+					next = append(next, w)
+				}
+			}
+		}
+		cur = next
+	}
+	t.finish(adj, indeg)
+	return t
+}
+
+// 字典序最小的拓扑排序, 每次取出入度为 0 的顶点中编号最小的一个
+// prque 是以 float32 为优先级的最大堆, 编号取负作为优先级, 2^24 以内的编号是精确的
+func NewLexTopological(dig SimpleDigraph) LayeredTopological {
+	adj, indeg, t := newKahn(dig)
+	pq := prque.New()
+	for v := range adj {
+		if indeg[v] == 0 {
+			pq.Push(v, -float32(v))
+		}
+	}
+	for !pq.Empty() {
+		v := pq.PopItem().(int)
+		t.order = append(t.order, v)
+		for _, w := range adj[v] {
+			if indeg[w]--; indeg[w] == 0 {
+				pq.Push(w, -float32(w))
+			}
+		}
+	}
+	t.finish(adj, indeg)
+	return t
+}
+
+func newKahn(dig SimpleDigraph) ([][]int, []int, *KahnTopological) {
+	adj := adjacency(dig)
+	indeg := make([]int, len(adj))
+	for v := range adj {
+		for _, w := range adj[v] {
+			indeg[w]++
+		}
+	}
+	return adj, indeg, &KahnTopological{order: make([]int, 0, len(adj))}
+}
+
+// 根据取出的顺序计算分层, 或者在剩下的顶点里找出一个环
+func (self *KahnTopological) finish(adj [][]int, indeg []int) {
+	n := len(adj)
+	self.layer = make([]int, n)
+	for v := range self.layer {
+		self.layer[v] = -1
+	}
+	if len(self.order) < n {
+		self.order = nil
+		self.findCycle(adj, indeg)
+		return
+	}
+	// 按拓扑序松弛, 顶点的层号是所有前驱的层号加一的最大值
+	for _, v := range self.order {
+		if self.layer[v] < 0 {
+			self.layer[v] = 0
+		}
+		for _, w := range adj[v] {
+			if self.layer[w] < self.layer[v]+1 {
+				self.layer[w] = self.layer[v] + 1
+			}
+		}
+		if l := self.layer[v]; l == len(self.layers) {
+			self.layers = append(self.layers, []int{v})
+		} else {
+			self.layers[l] = append(self.layers[l], v)
+		}
+	}
+	for _, l := range self.layers {
+		sort.Ints(l)
+	}
+}
+
+// 剩下的顶点入度都大于 0, 且这些入边都来自剩下的顶点,
+// 所以从任一剩下的顶点沿入边往回走, 一定会走回到走过的顶点上
+func (self *KahnTopological) findCycle(adj [][]int, indeg []int) {
+	pred := make([]int, len(adj))
+	start := -1
+	for v := range adj {
+		if indeg[v] == 0 {
+			continue
+		}
+		start = v
+		for _, w := range adj[v] {
+			if indeg[w] > 0 {
+				pred[w] = v
+			}
+		}
+	}
+	step := make([]int, len(adj))
+	for v := range step {
+		step[v] = -1
+	}
+	path := make([]int, 0)
+	x := start
+	for step[x] < 0 {
+		step[x] = len(path)
+		path = append(path, x)
+		x = pred[x]
+	}
+	// path[step[x]:] 是沿入边走的一圈, 倒过来就是沿出边的环
+	ring := path[step[x]:]
+	self.cycle = make([]int, 0, len(ring)+1)
+	self.cycle = append(self.cycle, x)
+	for i := len(ring) - 1; i >= 0; i-- {
+		self.cycle = append(self.cycle, ring[i])
+	}
+}
+
+func (self *KahnTopological) IsDAG() bool {
+	return self.cycle == nil
+}
+
+func (self *KahnTopological) Order() []int {
+	return self.order
+}
+
+func (self *KahnTopological) Layers() [][]int {
+	return self.layers
+}
+
+func (self *KahnTopological) Layer(v int) int {
+	return self.layer[v]
+}
+
+func (self *KahnTopological) Cycle() []int {
+	return self.cycle
+}
@@ -0,0 +1,116 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+// 检查 order 是合法的拓扑序, 且分层满足每条边都从低层指向高层
+func assertLayered(t *testing.T, dig SimpleDigraph, tl LayeredTopological) {
+	pos := make([]int, dig.V())
+	for i, v := range tl.Order() {
+		pos[v] = i
+	}
+	assert.Equal(t, dig.V(), len(tl.Order()))
+	count := 0
+	for i, l := range tl.Layers() {
+		count += len(l)
+		for _, v := range l {
+			assert.Equal(t, i, tl.Layer(v))
+		}
+	}
+	assert.Equal(t, dig.V(), count)
+	for v := 0; v < dig.V(); v++ {
+		hasPred := false
+		for _, w := range dig.Adj(v) {
+			assert.True(t, pos[v] < pos[w], "%d -> %d", v, w)
+			assert.True(t, tl.Layer(v) < tl.Layer(w), "%d -> %d", v, w)
+		}
+		for u := 0; u < dig.V(); u++ {
+			if contains(dig.Adj(u), v) && tl.Layer(u) == tl.Layer(v)-1 {
+				hasPred = true
+			}
+		}
+		// 不在第 0 层的顶点一定有前驱在上一层
+		assert.Equal(t, tl.Layer(v) > 0, hasPred, "layer(%d)", v)
+	}
+}
+
+func assertDirectedCycle(t *testing.T, dig SimpleDigraph, cycle []int) {
+	assert.True(t, len(cycle) >= 2)
+	assert.Equal(t, cycle[0], cycle[len(cycle)-1])
+	for i := 0; i+1 < len(cycle); i++ {
+		assert.True(t, contains(dig.Adj(cycle[i]), cycle[i+1]), "%d -> %d", cycle[i], cycle[i+1])
+	}
+}
+
+func TestKahnTopological(t *testing.T) {
+	tl := NewKahnTopological(dag)
+	assert.True(t, tl.IsDAG())
+	assert.Nil(t, tl.Cycle())
+	assertLayered(t, dag, tl)
+	t.Log(tl.Order())
+	t.Log(tl.Layers())
+
+	lex := NewLexTopological(dag)
+	assertLayered(t, dag, lex)
+	assert.Equal(t, []int{2, 0, 1, 3, 5, 8, 7, 6, 4, 9, 10, 11, 12}, lex.Order())
+	assert.Equal(t, tl.Layers(), lex.Layers())
+}
+
+func TestLexTopological(t *testing.T) {
+	// 4 -> 0, 3 -> 1; 字典序最小是 2 3 1 4 0, 而逐层的顺序是 2 3 4 0 1
+	d := NewDigraph(5)
+	d.AddEdge(4, 0)
+	d.AddEdge(3, 1)
+	assert.Equal(t, []int{2, 3, 1, 4, 0}, NewLexTopological(d).Order())
+	assert.Equal(t, []int{2, 3, 4, 0, 1}, NewKahnTopological(d).Order())
+	assert.Equal(t, [][]int{{2, 3, 4}, {0, 1}}, NewKahnTopological(d).Layers())
+}
+
+func TestKahnTopologicalCycle(t *testing.T) {
+	// 0 -> 1 -> 2 -> 3 -> 1, 3 -> 4
+	d := NewDigraph(5)
+	d.AddEdge(0, 1)
+	d.AddEdge(1, 2)
+	d.AddEdge(2, 3)
+	d.AddEdge(3, 1)
+	d.AddEdge(3, 4)
+	for _, tl := range []LayeredTopological{NewKahnTopological(d), NewLexTopological(d)} {
+		assert.False(t, tl.IsDAG())
+		assert.Nil(t, tl.Order())
+		assert.Nil(t, tl.Layers())
+		assert.Equal(t, -1, tl.Layer(0))
+		assertDirectedCycle(t, d, tl.Cycle())
+		assert.Equal(t, 4, len(tl.Cycle()))
+		t.Log(tl.Cycle())
+	}
+}
+
+func TestKahnTopologicalRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		n := 30
+		perm := r.Perm(n)
+		d := NewDigraph(n)
+		for j := 0; j < 60; j++ {
+			a, b := r.Intn(n), r.Intn(n)
+			if perm[a] < perm[b] {
+				d.AddEdge(a, b)
+			}
+		}
+		assertLayered(t, d, NewKahnTopological(d))
+		assertLayered(t, d, NewLexTopological(d))
+		// 加一条逆着排列的边, 有可能成环, 结论要和 DirectedCycle 一致
+		a, b := r.Intn(n), r.Intn(n)
+		if perm[a] > perm[b] {
+			d.AddEdge(a, b)
+		}
+		tl := NewKahnTopological(d)
+		assert.Equal(t, NewDirectedCycle(d).HasCycle(), !tl.IsDAG())
+		if !tl.IsDAG() {
+			assertDirectedCycle(t, d, tl.Cycle())
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package tree
+
+import (
+	"math/bits"
+)
+
+// 最近公共祖先
+type LCA interface {
+	LCA(u, v int) int  // u 和 v 的最近公共祖先
+	Dist(u, v int) int // u 和 v 之间的边数
+}
+
+// 倍增法: up[k][v] 是 v 的第 2^k 个祖先, 预处理 O(V log V), 查询 O(log V)
+type BinaryLifting struct {
+	t  *Tree
+	up [][]int
+}
+
+func NewBinaryLifting(t *Tree) *BinaryLifting {
+	n := t.V()
+	levels := bits.Len(uint(n))
+	b := &BinaryLifting{t: t, up: make([][]int, levels)}
+	b.up[0] = make([]int, n)
+	for v := range b.up[0] {
+		b.up[0][v] = t.parent[v]
+		if v == t.root {
+			b.up[0][v] = v // 根的祖先就是它自己, 往上跳不会越界
+		}
+	}
+	for k := 1; k < levels; k++ {
+		b.up[k] = make([]int, n)
+		for v := range b.up[k] {
+			b.up[k][v] = b.up[k-1][b.up[k-1][v]]
+		}
+	}
+	return b
+}
+
+// v 的第 k 个祖先, k 超过 v 的深度时返回 -1
+func (self *BinaryLifting) Ancestor(v, k int) int {
+	if k > self.t.depth[v] {
+		return -1
+	}
+	for i := 0; k > 0; i++ {
+		if k&1 == 1 {
+			v = self.up[i][v]
+		}
+		k >>= 1
+	}
+	return v
+}
+
+// 先把深的顶点跳到同一深度, 再从高位到低位一起往上跳, 只要祖先不同就跳
+func (self *BinaryLifting) LCA(u, v int) int {
+	if self.t.depth[u] < self.t.depth[v] {
+		u, v = v, u
+	}
+	u = self.Ancestor(u, self.t.depth[u]-self.t.depth[v])
+	if u == v {
+		return u
+	}
+	for k := len(self.up) - 1; k >= 0; k-- {
+		if self.up[k][u] != self.up[k][v] {
+			u, v = self.up[k][u], self.up[k][v]
+		}
+	}
+	return self.up[0][u]
+}
+
+func (self *BinaryLifting) Dist(u, v int) int {
+	return dist(self.t, self, u, v)
+}
+
+// 欧拉序 + 稀疏表: 深度优先遍历时每进入一个顶点都记录一次, 共 2V-1 项,
+// u 和 v 的最近公共祖先是它们第一次出现的位置之间深度最小的顶点,
+// 用稀疏表做区间最小值查询, 预处理 O(V log V), 查询 O(1)
+type EulerTour struct {
+	t      *Tree
+	tour   []int
+	first  []int
+	sparse [][]int // sparse[k][i] 是 tour[i, i+2^k) 中深度最小的顶点
+}
+
+func NewEulerTour(t *Tree) *EulerTour {
+	n := t.V()
+	e := &EulerTour{t: t, tour: make([]int, 0, 2*n-1), first: make([]int, n)}
+	// 显式栈, next[v] 是 v 下一个要进入的子节点下标
+	next := make([]int, n)
+	sk := []int{t.root}
+	e.first[t.root] = 0
+	e.tour = append(e.tour, t.root)
+	for len(sk) > 0 {
+		v := sk[len(sk)-1]
+		if next[v] < len(t.children[v]) {
+			w := t.children[v][next[v]]
+			next[v]++
+			e.first[w] = len(e.tour)
+			e.tour = append(e.tour, w)
+			sk = append(sk, w)
+			continue
+		}
+		sk = sk[:len(sk)-1]
+		if len(sk) > 0 {
+			e.tour = append(e.tour, sk[len(sk)-1])
+		}
+	}
+	m := len(e.tour)
+	e.sparse = [][]int{e.tour}
+	for k := 1; 1<<uint(k) <= m; k++ {
+		prev, half := e.sparse[k-1], 1<<uint(k-1)
+		row := make([]int, m-1<<uint(k)+1)
+		for i := range row {
+			row[i] = e.shallower(prev[i], prev[i+half])
+		}
+		e.sparse = append(e.sparse, row)
+	}
+	return e
+}
+
+func (self *EulerTour) shallower(u, v int) int {
+	if self.t.depth[u] <= self.t.depth[v] {
+		return u
+	}
+	return v
+}
+
+// 欧拉序
+func (self *EulerTour) Tour() []int {
+	return self.tour
+}
+
+func (self *EulerTour) LCA(u, v int) int {
+	l, r := self.first[u], self.first[v]
+	if l > r {
+		l, r = r, l
+	}
+	k := bits.Len(uint(r-l+1)) - 1
+	return self.shallower(self.sparse[k][l], self.sparse[k][r-1<<uint(k)+1])
+}
+
+func (self *EulerTour) Dist(u, v int) int {
+	return dist(self.t, self, u, v)
+}
+
+func dist(t *Tree, lca LCA, u, v int) int {
+	return t.depth[u] + t.depth[v] - 2*t.depth[lca.LCA(u, v)]
+}
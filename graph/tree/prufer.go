@@ -0,0 +1,76 @@
+package tree
+
+import (
+	"github.com/cc14514/go-cookiekit/graph"
+)
+
+// Prüfer 序列: 有 n >= 2 个带编号顶点的树和长度为 n-2 的序列一一对应
+// 每次删掉编号最小的叶子并记下它的邻居, 直到只剩两个顶点
+// 指针 ptr 只会向前移动, 删掉叶子后邻居变成更小的叶子时直接处理它, 时间复杂度 O(V)
+func PruferEncode(g graph.SimpleGraph) []int {
+	n := g.V()
+	if n < 2 {
+		panic("error number")
+	}
+	// 以 n-1 为根, 删叶子时它的邻居就是父节点, 而 n-1 永远不会被删掉
+	t := Root(g, n-1)
+	degree := make([]int, n)
+	for v := 0; v < n; v++ {
+		degree[v] = len(t.children[v])
+		if v != n-1 {
+			degree[v]++
+		}
+	}
+	code := make([]int, n-2)
+	ptr := 0
+	for degree[ptr] != 1 {
+		ptr++
+	}
+	leaf := ptr
+	for i := range code {
+		next := t.parent[leaf]
+		code[i] = next
+		if degree[next]--; degree[next] == 1 && next < ptr {
+			leaf = next
+		} else {
+			for ptr++; degree[ptr] != 1; ptr++ {
+			}
+			leaf = ptr
+		}
+	}
+	return code
+}
+
+// 由 Prüfer 序列还原有 len(code)+2 个顶点的树
+// 顶点的度数是它在序列中出现的次数加一, 按编码的过程依次把最小的叶子接到序列中的顶点上
+func PruferDecode(code []int) *graph.Graph {
+	n := len(code) + 2
+	degree := make([]int, n)
+	for v := range degree {
+		degree[v] = 1
+	}
+	for _, v := range code {
+		if v < 0 || v >= n {
+			panic("error number")
+		}
+		degree[v]++
+	}
+	g := graph.NewGraph(n)
+	ptr := 0
+	for degree[ptr] != 1 {
+		ptr++
+	}
+	leaf := ptr
+	for _, v := range code {
+		g.AddEdge(leaf, v)
+		if degree[v]--; degree[v] == 1 && v < ptr {
+			leaf = v
+		} else {
+			for ptr++; degree[ptr] != 1; ptr++ {
+			}
+			leaf = ptr
+		}
+	}
+	g.AddEdge(leaf, n-1)
+	return g
+}
@@ -0,0 +1,163 @@
+// 树上的常用算法
+//
+// 无向图连通且无环时是一棵树, Root 以指定顶点为根建立有根树, 提供父节点、深度、子树大小,
+// 两种最近公共祖先 (倍增和欧拉序 + 稀疏表), 直径和中心; 另外提供 Prüfer 序列的编码和解码
+package tree
+
+import (
+	"github.com/cc14514/go-cookiekit/graph"
+	"sort"
+)
+
+// 无向图是不是一棵树: 至少有一个顶点, 连通且无环
+// 连通图无环等价于 E = V - 1, 先比较边数, 也能排除 NewCycle 发现不了的起点上的自环
+func IsTree(g graph.SimpleGraph) bool {
+	if g.V() == 0 || g.E() != g.V()-1 {
+		return false
+	}
+	return graph.NewCC(g).Count() == 1 && !graph.NewCycle(g).HasCycle()
+}
+
+// 有根树
+type Tree struct {
+	root     int
+	parent   []int
+	depth    []int
+	size     []int
+	children [][]int
+	order    []int // 广度优先序, 父节点总在子节点前面
+}
+
+// 以 root 为根建立有根树, g 不是树时 panic
+func Root(g graph.SimpleGraph, root int) *Tree {
+	if !IsTree(g) {
+		panic("not a tree")
+	}
+	if root < 0 || root >= g.V() {
+		panic("error number")
+	}
+	n := g.V()
+	t := &Tree{root: root, parent: make([]int, n), depth: make([]int, n), size: make([]int, n), children: make([][]int, n)}
+	for v := range t.parent {
+		t.parent[v] = -1
+	}
+	t.order = append(make([]int, 0, n), root)
+	for i := 0; i < len(t.order); i++ {
+		v := t.order[i]
+		for _, w := range g.Adj(v) {
+			if w != t.parent[v] {
+				t.parent[w], t.depth[w] = v, t.depth[v]+1
+				t.children[v] = append(t.children[v], w)
+				t.order = append(t.order, w)
+			}
+		}
+		sort.Ints(t.children[v])
+	}
+	for i := n - 1; i >= 0; i-- {
+		v := t.order[i]
+		t.size[v]++
+		if p := t.parent[v]; p >= 0 {
+			t.size[p] += t.size[v]
+		}
+	}
+	return t
+}
+
+func (self *Tree) V() int {
+	return len(self.parent)
+}
+
+func (self *Tree) Root() int {
+	return self.root
+}
+
+// 父节点, 根的父节点是 -1
+func (self *Tree) Parent(v int) int {
+	return self.parent[v]
+}
+
+// 到根的边数
+func (self *Tree) Depth(v int) int {
+	return self.depth[v]
+}
+
+// 以 v 为根的子树的顶点数
+func (self *Tree) Size(v int) int {
+	return self.size[v]
+}
+
+// 按编号排序的子节点
+func (self *Tree) Children(v int) []int {
+	return self.children[v]
+}
+
+// 广度优先序
+func (self *Tree) Order() []int {
+	return self.order
+}
+
+// u 到 v 的路径, 两端同时沿父节点往上走到相遇, 时间复杂度 O(路径长度)
+func (self *Tree) Path(u, v int) []int {
+	left, right := []int{u}, []int{v}
+	for u != v {
+		if self.depth[u] >= self.depth[v] {
+			u = self.parent[u]
+			left = append(left, u)
+		} else {
+			v = self.parent[v]
+			right = append(right, v)
+		}
+	}
+	for i := len(right) - 2; i >= 0; i-- {
+		left = append(left, right[i])
+	}
+	return left
+}
+
+// 直径: 树上最长的路径
+// 从根出发最远的顶点一定是直径的一个端点, 再从它出发找最远的顶点, 返回边数和路径
+func (self *Tree) Diameter() (int, []int) {
+	a := self.farthest(self.root)
+	b := self.farthest(a)
+	path := self.Path(a, b)
+	return len(path) - 1, path
+}
+
+// 中心: 离最远顶点的距离 (离心率) 最小的顶点, 是直径的中点, 有一个或两个
+func (self *Tree) Center() []int {
+	d, path := self.Diameter()
+	if d%2 == 0 {
+		return []int{path[d/2]}
+	}
+	c := []int{path[d/2], path[d/2+1]}
+	sort.Ints(c)
+	return c
+}
+
+// 离 s 最远的顶点中编号最小的一个
+func (self *Tree) farthest(s int) int {
+	dist := make([]int, self.V())
+	for v := range dist {
+		dist[v] = -1
+	}
+	dist[s] = 0
+	best := s
+	queue := []int{s}
+	for i := 0; i < len(queue); i++ {
+		v := queue[i]
+		if dist[v] > dist[best] || dist[v] == dist[best] && v < best {
+			best = v
+		}
+		next := self.children[v]
+		if p := self.parent[v]; p >= 0 {
+			next = append([]int{p}, next...)
+		}
+		for _, w := range next {
+			if dist[w] < 0 {
+				dist[w] = dist[v] + 1
+				queue = append(queue, w)
+			}
+		}
+	}
+	return best
+}
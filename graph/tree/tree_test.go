@@ -0,0 +1,177 @@
+package tree
+
+import (
+	"github.com/cc14514/go-cookiekit/graph"
+	"github.com/cc14514/go-cookiekit/graph/generate"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func randomTree(r *rand.Rand, n int) *graph.Graph {
+	code := make([]int, n-2)
+	for i := range code {
+		code[i] = r.Intn(n)
+	}
+	return PruferDecode(code)
+}
+
+// 广度优先求 s 到所有顶点的距离
+func bfs(g graph.SimpleGraph, s int) []int {
+	dist := make([]int, g.V())
+	for v := range dist {
+		dist[v] = -1
+	}
+	dist[s] = 0
+	queue := []int{s}
+	for i := 0; i < len(queue); i++ {
+		for _, w := range g.Adj(queue[i]) {
+			if dist[w] < 0 {
+				dist[w] = dist[queue[i]] + 1
+				queue = append(queue, w)
+			}
+		}
+	}
+	return dist
+}
+
+func TestIsTree(t *testing.T) {
+	assert.True(t, IsTree(generate.Path(10)))
+	assert.True(t, IsTree(generate.Star(10)))
+	assert.True(t, IsTree(generate.BinaryTree(10)))
+	assert.True(t, IsTree(graph.NewGraph(1)))
+	assert.False(t, IsTree(graph.NewGraph(0)))
+	assert.False(t, IsTree(graph.NewGraph(2)))
+	assert.False(t, IsTree(generate.Cycle(10)))
+	g := graph.NewGraph(3)
+	g.AddEdge(0, 0)
+	g.AddEdge(1, 2)
+	assert.False(t, IsTree(g))
+	assert.Panics(t, func() { Root(generate.Cycle(5), 0) })
+}
+
+func TestRoot(t *testing.T) {
+	// 0 - 1 - 3
+	//     |
+	//     2 - 4 - 5
+	g := graph.NewGraph(6)
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 3)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 4)
+	g.AddEdge(4, 5)
+	tr := Root(g, 1)
+	assert.Equal(t, []int{1, -1, 1, 1, 2, 4}, []int{tr.Parent(0), tr.Parent(1), tr.Parent(2), tr.Parent(3), tr.Parent(4), tr.Parent(5)})
+	assert.Equal(t, []int{0, 2, 3}, tr.Children(1))
+	assert.Equal(t, 6, tr.Size(1))
+	assert.Equal(t, 3, tr.Size(2))
+	assert.Equal(t, 3, tr.Depth(5))
+	assert.Equal(t, []int{0, 1, 2, 4, 5}, tr.Path(0, 5))
+	d, path := tr.Diameter()
+	assert.Equal(t, 4, d)
+	assert.Equal(t, 5, len(path))
+	assert.Equal(t, []int{2}, tr.Center())
+
+	b := NewBinaryLifting(tr)
+	e := NewEulerTour(tr)
+	assert.Equal(t, 1, b.LCA(0, 5))
+	assert.Equal(t, 2, e.LCA(5, 2))
+	assert.Equal(t, 4, b.Dist(0, 5))
+	assert.Equal(t, 2, b.Ancestor(5, 2))
+	assert.Equal(t, -1, b.Ancestor(5, 4))
+	assert.Equal(t, []int{1, 0, 1, 2, 4, 5, 4, 2, 1, 3, 1}, e.Tour())
+}
+
+func TestRandomTrees(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 30; i++ {
+		n := 2 + r.Intn(60)
+		g := randomTree(r, n)
+		assert.True(t, IsTree(g))
+		tr := Root(g, r.Intn(n))
+		b, e := NewBinaryLifting(tr), NewEulerTour(tr)
+		dist := make([][]int, n)
+		for v := range dist {
+			dist[v] = bfs(g, v)
+		}
+		for j := 0; j < 100; j++ {
+			u, v := r.Intn(n), r.Intn(n)
+			path := tr.Path(u, v)
+			// 路径上深度最小的顶点就是最近公共祖先
+			lca := path[0]
+			for _, x := range path {
+				if tr.Depth(x) < tr.Depth(lca) {
+					lca = x
+				}
+			}
+			assert.Equal(t, lca, b.LCA(u, v))
+			assert.Equal(t, lca, e.LCA(u, v))
+			assert.Equal(t, dist[u][v], len(path)-1)
+			assert.Equal(t, dist[u][v], b.Dist(u, v))
+			assert.Equal(t, dist[u][v], e.Dist(u, v))
+		}
+		// 直径是最大的距离, 中心是离心率最小的顶点
+		diameter, radius := 0, n
+		ecc := make([]int, n)
+		for v := range dist {
+			for _, d := range dist[v] {
+				if d > ecc[v] {
+					ecc[v] = d
+				}
+			}
+			if ecc[v] > diameter {
+				diameter = ecc[v]
+			}
+			if ecc[v] < radius {
+				radius = ecc[v]
+			}
+		}
+		center := make([]int, 0)
+		for v := range ecc {
+			if ecc[v] == radius {
+				center = append(center, v)
+			}
+		}
+		d, path := tr.Diameter()
+		assert.Equal(t, diameter, d)
+		assert.Equal(t, d, dist[path[0]][path[len(path)-1]])
+		assert.Equal(t, center, tr.Center())
+	}
+}
+
+func TestPrufer(t *testing.T) {
+	// 经典例子: 序列 3 3 3 4 对应的树
+	g := PruferDecode([]int{3, 3, 3, 4})
+	adj := func(v int) []int {
+		r := g.Adj(v)
+		sort.Ints(r)
+		return r
+	}
+	assert.Equal(t, []int{0, 1, 2, 4}, adj(3))
+	assert.Equal(t, []int{3, 5}, adj(4))
+	assert.Equal(t, []int{3, 3, 3, 4}, PruferEncode(g))
+	assert.Equal(t, []int{}, PruferEncode(generate.Path(2)))
+	assert.Equal(t, []int{0, 0, 0}, PruferEncode(generate.Star(5)))
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		n := 2 + r.Intn(30)
+		code := make([]int, n-2)
+		for j := range code {
+			code[j] = r.Intn(n)
+		}
+		assert.Equal(t, code, PruferEncode(PruferDecode(code)))
+	}
+	assert.Panics(t, func() { PruferDecode([]int{5}) })
+}
+
+func TestLargePath(t *testing.T) {
+	n := 1 << 18
+	tr := Root(generate.Path(n), 0)
+	b, e := NewBinaryLifting(tr), NewEulerTour(tr)
+	assert.Equal(t, 1000, b.LCA(1000, n-1))
+	assert.Equal(t, 1000, e.LCA(n-1, 1000))
+	d, _ := tr.Diameter()
+	assert.Equal(t, n-1, d)
+}
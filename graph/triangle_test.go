@@ -0,0 +1,65 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestTriangles(t *testing.T) {
+	// 两个三角形 0-1-2 和 3-4-5
+	tr := NewTriangles(NewGraphByData(data1))
+	assert.Equal(t, 2, tr.Count())
+	assert.Equal(t, 1, tr.CountOf(0))
+	assert.Equal(t, 1.0, tr.Clustering(0))
+	assert.Equal(t, 1.0, tr.AverageClustering())
+	assert.Equal(t, 1.0, tr.Transitivity())
+
+	// data2: 三角形 0-1-5, 1-2-3, 1-3-5, 3-4-5
+	tr = NewTriangles(NewGraphByAdjacencyList(data2))
+	assert.Equal(t, 4, tr.Count())
+	assert.Equal(t, 3, tr.CountOf(1))
+	assert.Equal(t, 3, tr.CountOf(3))
+	assert.InDelta(t, 3.0/6, tr.Clustering(1), 1e-12)
+	assert.InDelta(t, 3.0*4/(1+6+1+6+1+6), tr.Transitivity(), 1e-12)
+
+	star := NewTriangles(pathGraph(2))
+	assert.Equal(t, 0, star.Count())
+	assert.Equal(t, 0.0, star.Clustering(0))
+	assert.Equal(t, 0.0, star.Transitivity())
+}
+
+func TestTrianglesRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	g := NewGraph(60)
+	for i := 0; i < 400; i++ {
+		g.AddEdge(r.Intn(60), r.Intn(60))
+	}
+	// 暴力枚举所有三元组作为对照
+	has := func(v, w int) bool {
+		for _, x := range g.Adj(v) {
+			if x == w {
+				return true
+			}
+		}
+		return false
+	}
+	count, local := 0, make([]int, 60)
+	for a := 0; a < 60; a++ {
+		for b := a + 1; b < 60; b++ {
+			for c := b + 1; c < 60; c++ {
+				if has(a, b) && has(b, c) && has(a, c) {
+					count++
+					local[a]++
+					local[b]++
+					local[c]++
+				}
+			}
+		}
+	}
+	tr := NewTriangles(g)
+	assert.Equal(t, count, tr.Count())
+	for v := 0; v < 60; v++ {
+		assert.Equal(t, local[v], tr.CountOf(v))
+	}
+}
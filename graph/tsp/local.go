@@ -0,0 +1,163 @@
+package tsp
+
+import (
+	"time"
+)
+
+// 局部优化的预算, 为 0 的字段表示不限制
+type Budget struct {
+	Iterations int           // 最多做多少次改进
+	Timeout    time.Duration // 最长运行时间
+}
+
+// 改进量小于 epsilon 时视为没有改进, 避免浮点误差导致来回移动
+const epsilon = 1e-9
+
+type budget struct {
+	left     int // 剩余的改进次数, 小于 0 表示不限制
+	deadline time.Time
+	done     bool
+}
+
+func newBudget(b Budget) *budget {
+	r := &budget{left: -1}
+	if b.Iterations > 0 {
+		r.left = b.Iterations
+	}
+	if b.Timeout > 0 {
+		r.deadline = time.Now().Add(b.Timeout)
+	}
+	return r
+}
+
+// 检查是否还有时间
+func (self *budget) alive() bool {
+	if !self.done && !self.deadline.IsZero() && time.Now().After(self.deadline) {
+		self.done = true
+	}
+	return !self.done
+}
+
+// 记录一次改进, 返回是否还能继续
+func (self *budget) spend() bool {
+	if self.left > 0 {
+		if self.left--; self.left == 0 {
+			self.done = true
+		}
+	}
+	return self.alive()
+}
+
+// 2-opt: 删掉两条边 (a, b) 和 (c, d), 换成 (a, c) 和 (b, d), 即把 b 到 c 的一段反转
+// 反复做能缩短环游的交换直到没有改进或预算用完, 要求距离是对称的, 每一轮扫描 O(n^2)
+func TwoOpt(dist [][]float64, tour []int, b Budget) []int {
+	tour = append([]int(nil), tour...)
+	twoOpt(dist, tour, newBudget(b))
+	return tour
+}
+
+// 在 tour 上原地做 2-opt, 返回是否有过改进
+func twoOpt(dist [][]float64, tour []int, bg *budget) bool {
+	n := len(tour)
+	improved := false
+	for again := true; again; {
+		again = false
+		for i := 0; i < n-1 && bg.alive(); i++ {
+			a, b := tour[i], tour[i+1]
+			for j := i + 2; j < n; j++ {
+				c, d := tour[j], tour[(j+1)%n]
+				if d == a {
+					continue
+				}
+				if dist[a][c]+dist[b][d] < dist[a][b]+dist[c][d]-epsilon {
+					for l, r := i+1, j; l < r; l, r = l+1, r-1 {
+						tour[l], tour[r] = tour[r], tour[l]
+					}
+					improved, again = true, true
+					if !bg.spend() {
+						return improved
+					}
+					b = tour[i+1]
+				}
+			}
+		}
+	}
+	return improved
+}
+
+// Or-opt: 把连续 1 到 3 个顶点组成的一段移到环游的另一个位置, 可以反向插入
+// 反复做能缩短环游的移动直到没有改进或预算用完, 要求距离是对称的, 每一轮扫描 O(n^2)
+func OrOpt(dist [][]float64, tour []int, b Budget) []int {
+	tour = append([]int(nil), tour...)
+	orOpt(dist, tour, newBudget(b))
+	return normalize(tour)
+}
+
+func orOpt(dist [][]float64, tour []int, bg *budget) bool {
+	n := len(tour)
+	improved := false
+	for again := true; again; {
+		again = false
+		for l := 1; l <= 3 && l+2 <= n; l++ {
+			for i := 0; i < n && bg.alive(); i++ {
+				// 段是 tour[i .. i+l-1] (循环下标), p 和 q 是它前后的顶点
+				s0, sl := tour[i], tour[(i+l-1)%n]
+				p, q := tour[(i+n-1)%n], tour[(i+l)%n]
+				gain := dist[p][s0] + dist[sl][q] - dist[p][q]
+				// 插入到不与段相邻的边 (a, b) 之间
+				for k := l; k < n-1; k++ {
+					a, b := tour[(i+k)%n], tour[(i+k+1)%n]
+					forward := dist[a][s0] + dist[sl][b] - dist[a][b]
+					backward := dist[a][sl] + dist[s0][b] - dist[a][b]
+					if forward >= gain-epsilon && backward >= gain-epsilon {
+						continue
+					}
+					move(tour, i, l, k, backward < forward)
+					improved, again = true, true
+					if !bg.spend() {
+						return improved
+					}
+					break
+				}
+			}
+		}
+	}
+	return improved
+}
+
+// 把从 i 开始长为 l 的一段移到它后面第 k 个位置的顶点之后, 所有下标都是循环的
+func move(tour []int, i, l, k int, reversed bool) {
+	n := len(tour)
+	seg := make([]int, l)
+	for j := range seg {
+		seg[j] = tour[(i+j)%n]
+	}
+	if reversed {
+		for a, b := 0, l-1; a < b; a, b = a+1, b-1 {
+			seg[a], seg[b] = seg[b], seg[a]
+		}
+	}
+	// 段后面到插入点的 k-l+1 个顶点前移 l 位, 再把段放到它们后面
+	for j := 0; j < k-l+1; j++ {
+		tour[(i+j)%n] = tour[(i+l+j)%n]
+	}
+	for j := range seg {
+		tour[(i+k-l+1+j)%n] = seg[j]
+	}
+}
+
+// 交替使用 2-opt 和 Or-opt, 直到两者都没有改进或预算用完
+func Improve(dist [][]float64, tour []int, b Budget) []int {
+	tour = append([]int(nil), tour...)
+	bg := newBudget(b)
+	for bg.alive() {
+		a := twoOpt(dist, tour, bg)
+		if !bg.alive() {
+			break
+		}
+		if o := orOpt(dist, tour, bg); !a && !o {
+			break
+		}
+	}
+	return normalize(tour)
+}
@@ -0,0 +1,216 @@
+// 旅行商问题: 在带权完全图上求经过每个顶点恰好一次并回到起点的最短环游
+//
+// 距离用矩阵表示, dist[i][j] 是 i 到 j 的距离, FromGraph 可以把加权无向图转换成距离矩阵
+// 环游按访问顺序排列所有顶点, 从顶点 0 开始, 不重复起点
+// HeldKarp 是精确解, 只适用于很小的 n; NearestNeighbor 和 Christofides 用来构造初始解,
+// 再用 TwoOpt, OrOpt 或 Improve 做局部优化
+package tsp
+
+import (
+	"fmt"
+	"github.com/cc14514/go-cookiekit/graph"
+	"math"
+)
+
+// HeldKarp 能处理的最大顶点数, 需要 O(2^n * n) 的内存
+const MaxHeldKarp = 20
+
+// 加权无向图的距离矩阵, 平行边取最小的权重, 没有边的顶点之间为 +Inf
+func FromGraph(g graph.WeightedGraph) [][]float64 {
+	n := g.V()
+	dist := make([][]float64, n)
+	for i := range dist {
+		dist[i] = make([]float64, n)
+		for j := range dist[i] {
+			if i != j {
+				dist[i][j] = math.Inf(1)
+			}
+		}
+	}
+	for _, e := range g.Edges() {
+		if e.V != e.W && e.Weight < dist[e.V][e.W] {
+			dist[e.V][e.W], dist[e.W][e.V] = e.Weight, e.Weight
+		}
+	}
+	return dist
+}
+
+// 环游的长度, 包括从最后一个顶点回到起点的距离
+func Length(dist [][]float64, tour []int) float64 {
+	l := 0.0
+	for i, v := range tour {
+		l += dist[v][tour[(i+1)%len(tour)]]
+	}
+	return l
+}
+
+// Held–Karp 动态规划求最优环游
+// cost[S][j] 是从 0 出发经过集合 S 中所有顶点并停在 j 的最短路径长度, S 不含 0,
+// 按集合从小到大递推, 时间复杂度 O(2^n * n^2), n 超过 MaxHeldKarp 时返回错误
+func HeldKarp(dist [][]float64) ([]int, float64, error) {
+	n := len(dist)
+	if n > MaxHeldKarp {
+		return nil, 0, fmt.Errorf("%d vertices exceed MaxHeldKarp (%d)", n, MaxHeldKarp)
+	}
+	if n <= 1 {
+		return make([]int, n), 0, nil
+	}
+	m := n - 1 // 顶点 j (1 <= j < n) 对应第 j-1 位
+	full := 1<<uint(m) - 1
+	cost := make([]float64, (full+1)*m)
+	parent := make([]int8, (full+1)*m)
+	for s := 1; s <= full; s++ {
+		for j := 0; j < m; j++ {
+			if s>>uint(j)&1 == 0 {
+				continue
+			}
+			prev := s &^ (1 << uint(j))
+			if prev == 0 {
+				cost[s*m+j], parent[s*m+j] = dist[0][j+1], -1
+				continue
+			}
+			best, arg := math.Inf(1), -1
+			for k := 0; k < m; k++ {
+				if prev>>uint(k)&1 == 1 {
+					if c := cost[prev*m+k] + dist[k+1][j+1]; c < best || arg < 0 {
+						best, arg = c, k
+					}
+				}
+			}
+			cost[s*m+j], parent[s*m+j] = best, int8(arg)
+		}
+	}
+	best, last := math.Inf(1), 0
+	for j := 0; j < m; j++ {
+		if c := cost[full*m+j] + dist[j+1][0]; c < best || j == 0 {
+			best, last = c, j
+		}
+	}
+	tour := make([]int, n)
+	for s, j, i := full, last, n-1; j >= 0; i-- {
+		tour[i] = j + 1
+		s, j = s&^(1<<uint(j)), int(parent[s*m+j])
+	}
+	return tour, best, nil
+}
+
+// 最近邻: 从 start 出发, 每次走到最近的还没有访问过的顶点, 时间复杂度 O(n^2)
+func NearestNeighbor(dist [][]float64, start int) []int {
+	n := len(dist)
+	visited := make([]bool, n)
+	tour := make([]int, 0, n)
+	for v := start; v >= 0; {
+		visited[v] = true
+		tour = append(tour, v)
+		next := -1
+		for w := 0; w < n; w++ {
+			if !visited[w] && (next < 0 || dist[v][w] < dist[v][next]) {
+				next = w
+			}
+		}
+		v = next
+	}
+	return normalize(tour)
+}
+
+// Christofides 算法, 距离对称且满足三角不等式时结果不超过最优解的 1.5 倍
+// 最小生成树中度数为奇数的顶点有偶数个, 在它们之间求最小权完美匹配, 加到树上后所有顶点度数都是偶数,
+// 沿欧拉回路走一遍并跳过已经访问过的顶点就得到环游
+// 最小权完美匹配用 NewWeightedBlossomMatching 在权重 C - dist 上求最大基数的最大权匹配, 时间复杂度 O(n^3)
+// 距离矩阵必须是完全的, 有 +Inf 或 NaN (例如 FromGraph 中缺失的边) 时返回错误
+func Christofides(dist [][]float64) ([]int, error) {
+	n := len(dist)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i != j && (math.IsInf(dist[i][j], 0) || math.IsNaN(dist[i][j])) {
+				return nil, fmt.Errorf("distance %d-%d is not finite", i, j)
+			}
+		}
+	}
+	if n <= 2 {
+		tour := make([]int, n)
+		for i := range tour {
+			tour[i] = i
+		}
+		return tour, nil
+	}
+	complete := graph.NewEdgeWeightedGraph(n)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			complete.AddEdge(graph.Edge{V: i, W: j, Weight: dist[i][j]})
+		}
+	}
+	tree := graph.NewPrimMST(complete).Edges()
+	degree := make([]int, n)
+	multi := make([][]int, n) // 欧拉多重图的邻接表, 存边的编号
+	ends := make([][2]int, 0, 2*n)
+	addEdge := func(v, w int) {
+		multi[v] = append(multi[v], len(ends))
+		multi[w] = append(multi[w], len(ends))
+		ends = append(ends, [2]int{v, w})
+		degree[v]++
+		degree[w]++
+	}
+	for _, e := range tree {
+		addEdge(e.V, e.W)
+	}
+	odd := make([]int, 0)
+	for v := range degree {
+		if degree[v]%2 == 1 {
+			odd = append(odd, v)
+		}
+	}
+	c := 0.0
+	for _, v := range odd {
+		for _, w := range odd {
+			c = math.Max(c, dist[v][w])
+		}
+	}
+	matching := graph.NewEdgeWeightedGraph(len(odd))
+	for i := range odd {
+		for j := i + 1; j < len(odd); j++ {
+			matching.AddEdge(graph.Edge{V: i, W: j, Weight: c + 1 - dist[odd[i]][odd[j]]})
+		}
+	}
+	m := graph.NewWeightedBlossomMatching(matching, true)
+	for i := range odd {
+		if j := m.Mate(i); i < j {
+			addEdge(odd[i], odd[j])
+		}
+	}
+	// Hierholzer 算法求欧拉回路, 第一次出现的顶点依次加入环游
+	used := make([]bool, len(ends))
+	next := make([]int, n)
+	visited := make([]bool, n)
+	tour := make([]int, 0, n)
+	sk := []int{0}
+	for len(sk) > 0 {
+		v := sk[len(sk)-1]
+		for next[v] < len(multi[v]) && used[multi[v][next[v]]] {
+			next[v]++
+		}
+		if next[v] == len(multi[v]) {
+			sk = sk[:len(sk)-1]
+			if !visited[v] {
+				visited[v] = true
+				tour = append(tour, v)
+			}
+			continue
+		}
+		e := multi[v][next[v]]
+		used[e] = true
+		w := ends[e][0] + ends[e][1] - v
+		sk = append(sk, w)
+	}
+	return normalize(tour), nil
+}
+
+// 旋转环游让它从顶点 0 开始
+func normalize(tour []int) []int {
+	for i, v := range tour {
+		if v == 0 {
+			return append(append(make([]int, 0, len(tour)), tour[i:]...), tour[:i]...)
+		}
+	}
+	return tour
+}
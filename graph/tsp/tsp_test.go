@@ -0,0 +1,163 @@
+package tsp
+
+import (
+	"github.com/cc14514/go-cookiekit/graph"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+)
+
+// 平面上随机点的欧氏距离矩阵
+func points(r *rand.Rand, n int) [][]float64 {
+	x, y := make([]float64, n), make([]float64, n)
+	for i := range x {
+		x[i], y[i] = r.Float64()*100, r.Float64()*100
+	}
+	dist := make([][]float64, n)
+	for i := range dist {
+		dist[i] = make([]float64, n)
+		for j := range dist[i] {
+			dist[i][j] = math.Hypot(x[i]-x[j], y[i]-y[j])
+		}
+	}
+	return dist
+}
+
+// 枚举以 0 开头的所有排列
+func bruteTSP(dist [][]float64) float64 {
+	n := len(dist)
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	best := math.Inf(1)
+	var dfs func(k int)
+	dfs = func(k int) {
+		if k == n {
+			best = math.Min(best, Length(dist, perm))
+			return
+		}
+		for i := k; i < n; i++ {
+			perm[k], perm[i] = perm[i], perm[k]
+			dfs(k + 1)
+			perm[k], perm[i] = perm[i], perm[k]
+		}
+	}
+	dfs(1)
+	return best
+}
+
+func assertTour(t *testing.T, n int, tour []int) {
+	assert.Equal(t, n, len(tour))
+	if n > 0 {
+		assert.Equal(t, 0, tour[0])
+	}
+	sorted := append([]int(nil), tour...)
+	sort.Ints(sorted)
+	for i, v := range sorted {
+		assert.Equal(t, i, v)
+	}
+}
+
+func TestFromGraph(t *testing.T) {
+	g := graph.NewEdgeWeightedGraph(3)
+	g.AddEdge(graph.Edge{V: 0, W: 1, Weight: 2})
+	g.AddEdge(graph.Edge{V: 1, W: 0, Weight: 1})
+	g.AddEdge(graph.Edge{V: 1, W: 2, Weight: 3})
+	dist := FromGraph(g)
+	assert.Equal(t, 1.0, dist[0][1])
+	assert.Equal(t, 3.0, dist[2][1])
+	assert.True(t, math.IsInf(dist[0][2], 1))
+	assert.Equal(t, 0.0, dist[1][1])
+	assert.Equal(t, 7.0, Length([][]float64{{0, 1, 5}, {1, 0, 1}, {5, 1, 0}}, []int{0, 1, 2}))
+}
+
+func TestHeldKarp(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 30; i++ {
+		n := 1 + r.Intn(8)
+		dist := points(r, n)
+		tour, length, err := HeldKarp(dist)
+		assert.NoError(t, err)
+		assertTour(t, n, tour)
+		assert.InDelta(t, Length(dist, tour), length, 1e-9)
+		assert.InDelta(t, bruteTSP(dist), length, 1e-9)
+	}
+	// 非对称的距离也适用
+	asym := [][]float64{{0, 1, 9}, {9, 0, 1}, {1, 9, 0}}
+	tour, length, _ := HeldKarp(asym)
+	assert.Equal(t, []int{0, 1, 2}, tour)
+	assert.Equal(t, 3.0, length)
+	tour, _, err := HeldKarp(points(r, MaxHeldKarp+1))
+	assert.Nil(t, tour)
+	assert.Error(t, err)
+}
+
+func TestConstruction(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 30; i++ {
+		n := 1 + r.Intn(10)
+		dist := points(r, n)
+		_, opt, _ := HeldKarp(dist)
+		nn := NearestNeighbor(dist, r.Intn(n))
+		assertTour(t, n, nn)
+		c, err := Christofides(dist)
+		assert.NoError(t, err)
+		assertTour(t, n, c)
+		// 欧氏距离满足三角不等式, Christofides 不超过最优解的 1.5 倍
+		assert.True(t, Length(dist, c) <= 1.5*opt+1e-9, "%v > 1.5 * %v", Length(dist, c), opt)
+	}
+	// 缺失的边不能参与匹配和跳过
+	dist := points(r, 5)
+	dist[1][3], dist[3][1] = math.Inf(1), math.Inf(1)
+	c, err := Christofides(dist)
+	assert.Nil(t, c)
+	assert.Error(t, err)
+	t.Log(err)
+}
+
+func TestLocalSearch(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	dist := points(r, 200)
+	nn := NearestNeighbor(dist, 0)
+	base := Length(dist, nn)
+	two := TwoOpt(dist, nn, Budget{})
+	or := OrOpt(dist, nn, Budget{})
+	both := Improve(dist, nn, Budget{})
+	for _, tour := range [][]int{two, or, both} {
+		assertTour(t, 200, tour)
+		assert.True(t, Length(dist, tour) < base)
+	}
+	assert.True(t, Length(dist, both) <= Length(dist, two)+1e-9)
+	t.Log(base, Length(dist, two), Length(dist, or), Length(dist, both))
+
+	// 每次改进都严格缩短环游, 预算越多结果越好
+	one := TwoOpt(dist, nn, Budget{Iterations: 1})
+	ten := TwoOpt(dist, nn, Budget{Iterations: 10})
+	assert.True(t, Length(dist, one) < base)
+	assert.True(t, Length(dist, ten) < Length(dist, one))
+
+	start := time.Now()
+	Improve(dist, nn, Budget{Timeout: time.Nanosecond})
+	assert.True(t, time.Since(start) < time.Second)
+
+	for i := 0; i < 30; i++ {
+		n := 1 + r.Intn(9)
+		dist := points(r, n)
+		_, opt, _ := HeldKarp(dist)
+		tour := Improve(dist, NearestNeighbor(dist, 0), Budget{})
+		assertTour(t, n, tour)
+		assert.True(t, Length(dist, tour) >= opt-1e-9)
+	}
+}
+
+func BenchmarkChristofides(b *testing.B) {
+	dist := points(rand.New(rand.NewSource(1)), 500)
+	for i := 0; i < b.N; i++ {
+		tour, _ := Christofides(dist)
+		Improve(dist, tour, Budget{})
+	}
+}
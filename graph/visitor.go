@@ -0,0 +1,269 @@
+package graph
+
+import (
+	"github.com/cc14514/go-cookiekit/collections/bitset"
+	"github.com/cc14514/go-cookiekit/collections/queue"
+	"github.com/cc14514/go-cookiekit/collections/stack"
+)
+
+// 用函数实现 Visitor, 为 nil 的回调等同于返回 VisitContinue
+type VisitorFuncs struct {
+	OnDiscoverVertex func(v int) VisitResult
+	OnFinishVertex   func(v int) VisitResult
+	OnTreeEdge       func(v, w int) VisitResult
+	OnBackEdge       func(v, w int) VisitResult
+	OnForwardEdge    func(v, w int) VisitResult
+	OnCrossEdge      func(v, w int) VisitResult
+}
+
+func (self *VisitorFuncs) DiscoverVertex(v int) VisitResult {
+	return callVertex(self.OnDiscoverVertex, v)
+}
+
+func (self *VisitorFuncs) FinishVertex(v int) VisitResult {
+	return callVertex(self.OnFinishVertex, v)
+}
+
+func (self *VisitorFuncs) TreeEdge(v, w int) VisitResult {
+	return callEdge(self.OnTreeEdge, v, w)
+}
+
+func (self *VisitorFuncs) BackEdge(v, w int) VisitResult {
+	return callEdge(self.OnBackEdge, v, w)
+}
+
+func (self *VisitorFuncs) ForwardEdge(v, w int) VisitResult {
+	return callEdge(self.OnForwardEdge, v, w)
+}
+
+func (self *VisitorFuncs) CrossEdge(v, w int) VisitResult {
+	return callEdge(self.OnCrossEdge, v, w)
+}
+
+func callVertex(f func(int) VisitResult, v int) VisitResult {
+	if f == nil {
+		return VisitContinue
+	}
+	return f(v)
+}
+
+func callEdge(f func(int, int) VisitResult, v, w int) VisitResult {
+	if f == nil {
+		return VisitContinue
+	}
+	return f(v, w)
+}
+
+// 顶点在遍历中的状态
+const (
+	white = iota // 未发现
+	gray         // 已发现, 还没有完成
+	black        // 已完成
+)
+
+// 深度优先遍历无向图, 每条边只回调一次: 树边或回边
+// sources 为空时按顶点编号依次遍历所有连通分量, 否则只从 sources 出发
+// 遍历被 VisitStop 终止时返回 false
+func DFSVisit(graph SimpleGraph, vis Visitor, sources ...int) bool {
+	return newDFSWalker(graph, vis, false).walk(sources)
+}
+
+// 深度优先遍历有向图, 每条边只回调一次: 树边、回边、前向边或横跨边
+// sources 为空时按顶点编号依次遍历所有顶点, 否则只从 sources 出发
+// 遍历被 VisitStop 终止时返回 false
+func DirectedDFSVisit(digraph SimpleDigraph, vis Visitor, sources ...int) bool {
+	return newDFSWalker(digraph, vis, true).walk(sources)
+}
+
+type dfsWalker struct {
+	graph    SimpleGraph
+	vis      Visitor
+	directed bool
+	color    []byte
+	disc     []int // 发现顶点的次序, 用来区分前向边和横跨边
+	time     int
+	sk       *stack.Stack
+}
+
+func newDFSWalker(graph SimpleGraph, vis Visitor, directed bool) *dfsWalker {
+	return &dfsWalker{
+		graph:    graph,
+		vis:      vis,
+		directed: directed,
+		color:    make([]byte, graph.V()),
+		disc:     make([]int, graph.V()),
+		sk:       stack.New(),
+	}
+}
+
+func (self *dfsWalker) walk(sources []int) bool {
+	if len(sources) == 0 {
+		for s := 0; s < self.graph.V(); s++ {
+			if self.color[s] == white && !self.visit(s) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, s := range sources {
+		if self.color[s] == white && !self.visit(s) {
+			return false
+		}
+	}
+	return true
+}
+
+// 发现顶点 v 并入栈, u 是 v 的父节点
+func (self *dfsWalker) discover(v, u int) bool {
+	self.color[v] = gray
+	self.disc[v] = self.time
+	self.time++
+	f := &dfsFrame{v: v, u: u}
+	switch self.vis.DiscoverVertex(v) {
+	case VisitStop:
+		return false
+	case VisitContinue:
+		f.adj = self.graph.Adj(v)
+	}
+	self.sk.Push(f)
+	return true
+}
+
+func (self *dfsWalker) visit(s int) bool {
+	if !self.discover(s, -1) {
+		return false
+	}
+	for !self.sk.Empty() {
+		f := self.sk.Top().(*dfsFrame)
+		w, ok := f.next()
+		if !ok {
+			self.color[f.v] = black
+			self.sk.Pop()
+			if self.vis.FinishVertex(f.v) == VisitStop {
+				return false
+			}
+			continue
+		}
+		var r VisitResult
+		switch self.color[w] {
+		case white:
+			r = self.vis.TreeEdge(f.v, w)
+			if r == VisitContinue && !self.discover(w, f.v) {
+				return false
+			}
+		case gray:
+			// 无向图中指回父节点的是树边本身
+			if !self.directed && w == f.u {
+				continue
+			}
+			r = self.vis.BackEdge(f.v, w)
+		case black:
+			// 无向图中指向已完成顶点的边, 在另一端已经作为回边处理过了
+			if !self.directed {
+				continue
+			}
+			if self.disc[f.v] < self.disc[w] {
+				r = self.vis.ForwardEdge(f.v, w)
+			} else {
+				r = self.vis.CrossEdge(f.v, w)
+			}
+		}
+		if r == VisitStop {
+			return false
+		}
+	}
+	return true
+}
+
+// 广度优先遍历无向图, 每条边只回调一次: 树边或横跨边
+// sources 为空时按顶点编号依次遍历所有连通分量,
+// 否则所有 sources 同时作为起点 (多源广度优先)
+// 遍历被 VisitStop 终止时返回 false
+func BFSVisit(graph SimpleGraph, vis Visitor, sources ...int) bool {
+	return newBFSWalker(graph, vis, false).walk(sources)
+}
+
+// 广度优先遍历有向图, 树边以外的边都作为横跨边回调, 不区分回边
+// sources 的含义同 BFSVisit
+// 遍历被 VisitStop 终止时返回 false
+func DirectedBFSVisit(digraph SimpleDigraph, vis Visitor, sources ...int) bool {
+	return newBFSWalker(digraph, vis, true).walk(sources)
+}
+
+type bfsWalker struct {
+	graph    SimpleGraph
+	vis      Visitor
+	directed bool
+	color    []byte
+	pruned   *bitset.Bitset // 被剪枝, 不展开邻接表的顶点
+	q        *queue.Queue
+}
+
+func newBFSWalker(graph SimpleGraph, vis Visitor, directed bool) *bfsWalker {
+	return &bfsWalker{
+		graph:    graph,
+		vis:      vis,
+		directed: directed,
+		color:    make([]byte, graph.V()),
+		pruned:   bitset.New(graph.V()),
+		q:        queue.New(),
+	}
+}
+
+func (self *bfsWalker) walk(sources []int) bool {
+	if len(sources) == 0 {
+		for s := 0; s < self.graph.V(); s++ {
+			if self.color[s] == white && !(self.discover(s) && self.visit()) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, s := range sources {
+		if self.color[s] == white && !self.discover(s) {
+			return false
+		}
+	}
+	return self.visit()
+}
+
+func (self *bfsWalker) discover(v int) bool {
+	self.color[v] = gray
+	switch self.vis.DiscoverVertex(v) {
+	case VisitStop:
+		return false
+	case VisitPrune:
+		self.pruned.Set(v)
+	}
+	self.q.Push(v)
+	return true
+}
+
+func (self *bfsWalker) visit() bool {
+	for !self.q.Empty() {
+		v := self.q.Pop().(int)
+		if !self.pruned.Test(v) {
+			for _, w := range self.graph.Adj(v) {
+				var r VisitResult
+				switch {
+				case self.color[w] == white:
+					r = self.vis.TreeEdge(v, w)
+					if r == VisitContinue && !self.discover(w) {
+						return false
+					}
+				case self.directed || self.color[w] == gray:
+					// 无向图中指向已完成顶点的边, 在另一端已经处理过了
+					r = self.vis.CrossEdge(v, w)
+				}
+				if r == VisitStop {
+					return false
+				}
+			}
+		}
+		self.color[v] = black
+		if self.vis.FinishVertex(v) == VisitStop {
+			return false
+		}
+	}
+	return true
+}
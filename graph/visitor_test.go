@@ -0,0 +1,174 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+// 邻接表按顺序返回的无向图, 保证遍历顺序是确定的
+type sortedGraph struct {
+	*Graph
+}
+
+func (self sortedGraph) Adj(v int) []int {
+	r := self.Graph.Adj(v)
+	sort.Ints(r)
+	return r
+}
+
+// 记录所有回调事件
+type recordVisitor struct {
+	events []string
+}
+
+func (self *recordVisitor) add(kind string, vs ...int) VisitResult {
+	e := kind
+	for _, v := range vs {
+		e += " " + strconv.Itoa(v)
+	}
+	self.events = append(self.events, e)
+	return VisitContinue
+}
+
+func (self *recordVisitor) DiscoverVertex(v int) VisitResult { return self.add("discover", v) }
+func (self *recordVisitor) FinishVertex(v int) VisitResult   { return self.add("finish", v) }
+func (self *recordVisitor) TreeEdge(v, w int) VisitResult    { return self.add("tree", v, w) }
+func (self *recordVisitor) BackEdge(v, w int) VisitResult    { return self.add("back", v, w) }
+func (self *recordVisitor) ForwardEdge(v, w int) VisitResult { return self.add("forward", v, w) }
+func (self *recordVisitor) CrossEdge(v, w int) VisitResult   { return self.add("cross", v, w) }
+
+func TestDirectedDFSVisit(t *testing.T) {
+	d := NewDigraph(5)
+	d.AddEdge(0, 1)
+	d.AddEdge(0, 2)
+	d.AddEdge(1, 2)
+	d.AddEdge(2, 0)
+	d.AddEdge(3, 1)
+	d.AddEdge(3, 4)
+	vis := new(recordVisitor)
+	assert.True(t, DirectedDFSVisit(sortedDigraph{d}, vis))
+	assert.Equal(t, []string{
+		"discover 0",
+		"tree 0 1",
+		"discover 1",
+		"tree 1 2",
+		"discover 2",
+		"back 2 0",
+		"finish 2",
+		"finish 1",
+		"forward 0 2",
+		"finish 0",
+		"discover 3",
+		"cross 3 1",
+		"tree 3 4",
+		"discover 4",
+		"finish 4",
+		"finish 3",
+	}, vis.events)
+}
+
+func TestDFSVisit(t *testing.T) {
+	g := NewGraphByAdjacencyList(data2)
+	tree, back := 0, 0
+	DFSVisit(sortedGraph{g}, &VisitorFuncs{
+		OnTreeEdge: func(v, w int) VisitResult {
+			tree++
+			return VisitContinue
+		},
+		OnBackEdge: func(v, w int) VisitResult {
+			back++
+			return VisitContinue
+		},
+		OnForwardEdge: func(v, w int) VisitResult {
+			t.Errorf("forward edge %d-%d in undirected graph", v, w)
+			return VisitContinue
+		},
+		OnCrossEdge: func(v, w int) VisitResult {
+			t.Errorf("cross edge %d-%d in undirected graph", v, w)
+			return VisitContinue
+		},
+	})
+	// 每条边只回调一次, 连通图的树边有 V-1 条
+	assert.Equal(t, g.V()-1, tree)
+	assert.Equal(t, g.E(), tree+back)
+}
+
+func TestBFSVisit(t *testing.T) {
+	g := NewGraphByAdjacencyList(data2)
+	order := []int{}
+	edges := 0
+	BFSVisit(sortedGraph{g}, &VisitorFuncs{
+		OnDiscoverVertex: func(v int) VisitResult {
+			order = append(order, v)
+			return VisitContinue
+		},
+		OnTreeEdge: func(v, w int) VisitResult {
+			edges++
+			return VisitContinue
+		},
+		OnCrossEdge: func(v, w int) VisitResult {
+			edges++
+			return VisitContinue
+		},
+	})
+	assert.Equal(t, []int{0, 1, 5, 2, 3, 4}, order)
+	assert.Equal(t, g.E(), edges)
+}
+
+func TestVisitPruneAndStop(t *testing.T) {
+	path := NewDigraph(6)
+	for v := 0; v+1 < 6; v++ {
+		path.AddEdge(v, v+1)
+	}
+	// 在 2 处剪枝, 3 以后的顶点都不会被发现
+	found := []int{}
+	prune := &VisitorFuncs{
+		OnDiscoverVertex: func(v int) VisitResult {
+			found = append(found, v)
+			if v == 2 {
+				return VisitPrune
+			}
+			return VisitContinue
+		},
+	}
+	assert.True(t, DirectedDFSVisit(path, prune, 0))
+	assert.Equal(t, []int{0, 1, 2}, found)
+	found = found[:0]
+	assert.True(t, DirectedBFSVisit(path, prune, 0))
+	assert.Equal(t, []int{0, 1, 2}, found)
+
+	// 不沿树边 1->2 前进
+	found = found[:0]
+	assert.True(t, DirectedDFSVisit(path, &VisitorFuncs{
+		OnDiscoverVertex: func(v int) VisitResult {
+			found = append(found, v)
+			return VisitContinue
+		},
+		OnTreeEdge: func(v, w int) VisitResult {
+			if w == 2 {
+				return VisitPrune
+			}
+			return VisitContinue
+		},
+	}, 0))
+	assert.Equal(t, []int{0, 1}, found)
+
+	// 在 3 处终止
+	found = found[:0]
+	stop := &VisitorFuncs{
+		OnDiscoverVertex: func(v int) VisitResult {
+			found = append(found, v)
+			if v == 3 {
+				return VisitStop
+			}
+			return VisitContinue
+		},
+	}
+	assert.False(t, DirectedDFSVisit(path, stop))
+	assert.Equal(t, []int{0, 1, 2, 3}, found)
+	found = found[:0]
+	assert.False(t, DirectedBFSVisit(path, stop))
+	assert.Equal(t, []int{0, 1, 2, 3}, found)
+}
@@ -0,0 +1,186 @@
+package graph
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+)
+
+// 带权重的无向边
+type Edge struct {
+	V, W   int
+	Weight float64
+}
+
+// 边的另一个顶点
+func (self Edge) Other(v int) int {
+	if v == self.V {
+		return self.W
+	}
+	return self.V
+}
+
+// 加权无向图, 允许平行边和自环
+type EdgeWeightedGraph struct {
+	v, e int
+	adj  [][]Edge //邻接表
+}
+
+func (self *EdgeWeightedGraph) V() int {
+	return self.v
+}
+
+func (self *EdgeWeightedGraph) E() int {
+	return self.e
+}
+
+func (self *EdgeWeightedGraph) AddEdge(e Edge) {
+	if e.V >= self.V() || e.W >= self.V() {
+		panic("error number")
+	}
+	self.adj[e.V] = append(self.adj[e.V], e)
+	if e.V != e.W { // 自环
+		self.adj[e.W] = append(self.adj[e.W], e)
+	}
+	self.e++
+}
+
+func (self *EdgeWeightedGraph) Adj(v int) []Edge {
+	if v >= len(self.adj) {
+		return nil
+	}
+	return self.adj[v]
+}
+
+func (self *EdgeWeightedGraph) Edges() []Edge {
+	r := make([]Edge, 0, self.e)
+	for v, edges := range self.adj {
+		for _, e := range edges {
+			// 每条边在两个端点都出现, 只在较小的一端取一次
+			if e.Other(v) >= v {
+				r = append(r, e)
+			}
+		}
+	}
+	return r
+}
+
+func (self *EdgeWeightedGraph) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("\n")
+	buf.WriteString(strconv.Itoa(self.V()))
+	buf.WriteString("\n")
+	buf.WriteString(strconv.Itoa(self.E()))
+	buf.WriteString("\n")
+	for _, e := range self.Edges() {
+		buf.WriteString(strconv.Itoa(e.V))
+		buf.WriteString(" ")
+		buf.WriteString(strconv.Itoa(e.W))
+		buf.WriteString(" ")
+		buf.WriteString(strconv.FormatFloat(e.Weight, 'g', -1, 64))
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
+
+func NewEdgeWeightedGraph(v int) (g *EdgeWeightedGraph) {
+	g = new(EdgeWeightedGraph)
+	g.v = v
+	g.adj = make([][]Edge, v)
+	return
+}
+
+// 把无权图转换成每条边权重都为 1 的加权图
+// 邻接顶点按编号排序后加边, 同一个图每次转换得到的边的顺序相同
+func NewEdgeWeightedGraphByGraph(graph SimpleGraph) (g *EdgeWeightedGraph) {
+	g = NewEdgeWeightedGraph(graph.V())
+	for v := 0; v < graph.V(); v++ {
+		adj := graph.Adj(v)
+		sort.Ints(adj)
+		for _, w := range adj {
+			if w >= v {
+				g.AddEdge(Edge{v, w, 1})
+			}
+		}
+	}
+	return
+}
+
+// 带权重的有向边 From->To
+type DirectedEdge struct {
+	From, To int
+	Weight   float64
+}
+
+// 加权有向图, 允许平行边和自环
+type EdgeWeightedDigraph struct {
+	v, e int
+	adj  [][]DirectedEdge //邻接表, 只包含从 v 出发的边
+}
+
+func (self *EdgeWeightedDigraph) V() int {
+	return self.v
+}
+
+func (self *EdgeWeightedDigraph) E() int {
+	return self.e
+}
+
+func (self *EdgeWeightedDigraph) AddEdge(e DirectedEdge) {
+	if e.From >= self.V() || e.To >= self.V() {
+		panic("error number")
+	}
+	self.adj[e.From] = append(self.adj[e.From], e)
+	self.e++
+}
+
+func (self *EdgeWeightedDigraph) Adj(v int) []DirectedEdge {
+	if v >= len(self.adj) {
+		return nil
+	}
+	return self.adj[v]
+}
+
+func (self *EdgeWeightedDigraph) Edges() []DirectedEdge {
+	r := make([]DirectedEdge, 0, self.e)
+	for _, edges := range self.adj {
+		r = append(r, edges...)
+	}
+	return r
+}
+
+func (self *EdgeWeightedDigraph) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("\n")
+	buf.WriteString(strconv.Itoa(self.V()))
+	buf.WriteString("\n")
+	buf.WriteString(strconv.Itoa(self.E()))
+	buf.WriteString("\n")
+	for _, e := range self.Edges() {
+		buf.WriteString(strconv.Itoa(e.From))
+		buf.WriteString(" ")
+		buf.WriteString(strconv.Itoa(e.To))
+		buf.WriteString(" ")
+		buf.WriteString(strconv.FormatFloat(e.Weight, 'g', -1, 64))
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
+
+func NewEdgeWeightedDigraph(v int) (g *EdgeWeightedDigraph) {
+	g = new(EdgeWeightedDigraph)
+	g.v = v
+	g.adj = make([][]DirectedEdge, v)
+	return
+}
+
+// 把无权有向图转换成每条边权重都为 1 的加权有向图
+func NewEdgeWeightedDigraphByDigraph(dig SimpleDigraph) (g *EdgeWeightedDigraph) {
+	g = NewEdgeWeightedDigraph(dig.V())
+	for v := 0; v < dig.V(); v++ {
+		for _, w := range dig.Adj(v) {
+			g.AddEdge(DirectedEdge{v, w, 1})
+		}
+	}
+	return
+}
@@ -0,0 +1,531 @@
+package graph
+
+// 一般图的最大权匹配, Edmonds 带花树原始-对偶算法, 时间复杂度 O(V^3)
+// 维护顶点和花的对偶变量, 只沿松弛为 0 的边扩展交错树, 扩展不下去时调整对偶变量,
+// 让新的边变紧, 或者让一朵外层的奇数层花的对偶变量降为 0 后把它展开
+// maxCardinality 为 true 时在所有最大基数匹配中求权重最大的, 否则只求权重最大的
+// 权重为整数时结果是精确的, 浮点数权重可能受舍入误差影响
+// 实现参照 Van Rantwijk 的 mwmatching, 端点 p 表示边 p/2 的一端, p^1 是另一端
+func NewWeightedBlossomMatching(graph WeightedGraph, maxCardinality bool) Matching {
+	edges := make([]Edge, 0, graph.E())
+	for _, e := range graph.Edges() {
+		if e.V != e.W {
+			edges = append(edges, e)
+		}
+	}
+	n := graph.V()
+	b := newWeightedBlossom(n, edges, maxCardinality)
+	b.solve()
+	m := newMatching(n)
+	for v, p := range b.mate {
+		if p >= 0 && v < b.endpoint[p] {
+			m.match(v, b.endpoint[p], edges[p/2].Weight)
+		}
+	}
+	return m
+}
+
+type weightedBlossom struct {
+	n              int
+	edges          []Edge
+	maxCardinality bool
+	endpoint       []int   // endpoint[p] 是端点 p 对应的顶点
+	neighbEnd      [][]int // 和 v 相连的边的远端端点
+	mate           []int   // 和 v 匹配的远端端点, 未匹配为 -1
+	// 编号小于 n 的是顶点 (平凡花), 其余是花
+	label            []int // 0 未标记, 1 偶数层 (S), 2 奇数层 (T)
+	labelEnd         []int // 通过哪个端点得到的标记
+	inBlossom        []int // 顶点所在的最外层花
+	blossomParent    []int
+	blossomChilds    [][]int // 花的子花, 从花根开始沿环排列
+	blossomBase      []int
+	blossomEndps     [][]int // 连接相邻子花的边的端点
+	bestEdge         []int   // 到偶数层花的松弛最小的边
+	blossomBestEdges [][]int // 偶数层花到其它偶数层花的最优边, nil 表示还没有计算
+	unused           []int
+	dualVar          []float64
+	allowEdge        []bool // 松弛为 0 的边
+	queue            []int
+}
+
+func newWeightedBlossom(n int, edges []Edge, maxCardinality bool) *weightedBlossom {
+	b := &weightedBlossom{
+		n: n, edges: edges, maxCardinality: maxCardinality,
+		endpoint: make([]int, 2*len(edges)), neighbEnd: make([][]int, n), mate: make([]int, n),
+		label: make([]int, 2*n), labelEnd: make([]int, 2*n), inBlossom: make([]int, n),
+		blossomParent: make([]int, 2*n), blossomChilds: make([][]int, 2*n), blossomBase: make([]int, 2*n),
+		blossomEndps: make([][]int, 2*n), bestEdge: make([]int, 2*n), blossomBestEdges: make([][]int, 2*n),
+		dualVar: make([]float64, 2*n), allowEdge: make([]bool, len(edges)),
+	}
+	maxWeight := 0.0
+	for k, e := range edges {
+		b.endpoint[2*k], b.endpoint[2*k+1] = e.V, e.W
+		b.neighbEnd[e.V] = append(b.neighbEnd[e.V], 2*k+1)
+		b.neighbEnd[e.W] = append(b.neighbEnd[e.W], 2*k)
+		if e.Weight > maxWeight {
+			maxWeight = e.Weight
+		}
+	}
+	for v := 0; v < n; v++ {
+		b.mate[v], b.inBlossom[v] = -1, v
+		b.dualVar[v] = maxWeight
+	}
+	for x := 0; x < 2*n; x++ {
+		b.labelEnd[x], b.blossomParent[x], b.bestEdge[x] = -1, -1, -1
+		b.blossomBase[x] = x
+		if x >= n {
+			b.blossomBase[x] = -1
+			b.unused = append(b.unused, x)
+		}
+	}
+	return b
+}
+
+func (self *weightedBlossom) slack(k int) float64 {
+	e := self.edges[k]
+	return self.dualVar[e.V] + self.dualVar[e.W] - 2*e.Weight
+}
+
+// 花 b 包含的所有顶点
+func (self *weightedBlossom) leaves(b int, f func(v int)) {
+	if b < self.n {
+		f(b)
+		return
+	}
+	for _, t := range self.blossomChilds[b] {
+		self.leaves(t, f)
+	}
+}
+
+// 负数下标从末尾开始, 和原实现中 Python 的下标一致
+func cyclicAt(s []int, i int) int {
+	if i < 0 {
+		i += len(s)
+	}
+	return s[i]
+}
+
+func (self *weightedBlossom) assignLabel(w, t, p int) {
+	b := self.inBlossom[w]
+	self.label[w], self.label[b] = t, t
+	self.labelEnd[w], self.labelEnd[b] = p, p
+	self.bestEdge[w], self.bestEdge[b] = -1, -1
+	if t == 1 {
+		self.leaves(b, func(v int) { self.queue = append(self.queue, v) })
+	} else if t == 2 {
+		// 奇数层花的花根一定是匹配的, 它的配偶成为偶数层
+		base := self.blossomBase[b]
+		self.assignLabel(self.endpoint[self.mate[base]], 1, self.mate[base]^1)
+	}
+}
+
+// 从 v 和 w 同时沿交错树往上走, 找到公共的花根说明发现了新的花, 返回花根; 否则是增广路, 返回 -1
+func (self *weightedBlossom) scanBlossom(v, w int) int {
+	path := make([]int, 0)
+	base := -1
+	for v != -1 || w != -1 {
+		b := self.inBlossom[v]
+		if self.label[b]&4 != 0 {
+			base = self.blossomBase[b]
+			break
+		}
+		path = append(path, b)
+		self.label[b] = 5
+		if self.labelEnd[b] == -1 {
+			v = -1 // 到了交错树的根
+		} else {
+			v = self.endpoint[self.labelEnd[b]]
+			b = self.inBlossom[v]
+			v = self.endpoint[self.labelEnd[b]]
+		}
+		if w != -1 {
+			v, w = w, v
+		}
+	}
+	for _, b := range path {
+		self.label[b] = 1
+	}
+	return base
+}
+
+// 把边 k 和两端到花根 base 的路径组成的奇环缩成一朵新花
+func (self *weightedBlossom) addBlossom(base, k int) {
+	v, w := self.edges[k].V, self.edges[k].W
+	bb, bv, bw := self.inBlossom[base], self.inBlossom[v], self.inBlossom[w]
+	b := self.unused[len(self.unused)-1]
+	self.unused = self.unused[:len(self.unused)-1]
+	self.blossomBase[b], self.blossomParent[b], self.blossomParent[bb] = base, -1, b
+	path, endps := make([]int, 0), make([]int, 0)
+	for bv != bb {
+		self.blossomParent[bv] = b
+		path = append(path, bv)
+		endps = append(endps, self.labelEnd[bv])
+		v = self.endpoint[self.labelEnd[bv]]
+		bv = self.inBlossom[v]
+	}
+	path = append(path, bb)
+	reverse(path)
+	reverse(endps)
+	endps = append(endps, 2*k)
+	for bw != bb {
+		self.blossomParent[bw] = b
+		path = append(path, bw)
+		endps = append(endps, self.labelEnd[bw]^1)
+		w = self.endpoint[self.labelEnd[bw]]
+		bw = self.inBlossom[w]
+	}
+	self.blossomChilds[b], self.blossomEndps[b] = path, endps
+	self.label[b], self.labelEnd[b], self.dualVar[b] = 1, self.labelEnd[bb], 0
+	self.leaves(b, func(v int) {
+		if self.label[self.inBlossom[v]] == 2 {
+			// 原来的奇数层顶点现在成了偶数层
+			self.queue = append(self.queue, v)
+		}
+		self.inBlossom[v] = b
+	})
+	// 合并子花到其它偶数层花的最优边
+	bestEdgeTo := make([]int, 2*self.n)
+	for i := range bestEdgeTo {
+		bestEdgeTo[i] = -1
+	}
+	for _, bv := range path {
+		var nbLists [][]int
+		if self.blossomBestEdges[bv] == nil {
+			self.leaves(bv, func(v int) {
+				list := make([]int, 0, len(self.neighbEnd[v]))
+				for _, p := range self.neighbEnd[v] {
+					list = append(list, p/2)
+				}
+				nbLists = append(nbLists, list)
+			})
+		} else {
+			nbLists = [][]int{self.blossomBestEdges[bv]}
+		}
+		for _, list := range nbLists {
+			for _, k := range list {
+				// 取边在花外的一端
+				j := self.edges[k].W
+				if self.inBlossom[j] == b {
+					j = self.edges[k].V
+				}
+				bj := self.inBlossom[j]
+				if bj != b && self.label[bj] == 1 && (bestEdgeTo[bj] == -1 || self.slack(k) < self.slack(bestEdgeTo[bj])) {
+					bestEdgeTo[bj] = k
+				}
+			}
+		}
+		self.blossomBestEdges[bv], self.bestEdge[bv] = nil, -1
+	}
+	best := make([]int, 0)
+	for _, k := range bestEdgeTo {
+		if k != -1 {
+			best = append(best, k)
+		}
+	}
+	self.blossomBestEdges[b], self.bestEdge[b] = best, -1
+	for _, k := range best {
+		if self.bestEdge[b] == -1 || self.slack(k) < self.slack(self.bestEdge[b]) {
+			self.bestEdge[b] = k
+		}
+	}
+}
+
+func reverse(s []int) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+func indexOf(s []int, x int) int {
+	for i, y := range s {
+		if y == x {
+			return i
+		}
+	}
+	return -1
+}
+
+// 展开外层花 b; endStage 为 true 时是一个阶段结束后展开对偶变量为 0 的花,
+// 否则是展开奇数层的花, 需要给沿环从入口到花根的偶数长度路径上的子花重新标记
+func (self *weightedBlossom) expandBlossom(b int, endStage bool) {
+	for _, s := range self.blossomChilds[b] {
+		self.blossomParent[s] = -1
+		if s < self.n {
+			self.inBlossom[s] = s
+		} else if endStage && self.dualVar[s] == 0 {
+			self.expandBlossom(s, endStage)
+		} else {
+			self.leaves(s, func(v int) { self.inBlossom[v] = s })
+		}
+	}
+	if !endStage && self.label[b] == 2 {
+		childs, endps := self.blossomChilds[b], self.blossomEndps[b]
+		entryChild := self.inBlossom[self.endpoint[self.labelEnd[b]^1]]
+		j := indexOf(childs, entryChild)
+		jStep, endpTrick := -1, 1
+		if j&1 != 0 {
+			j -= len(childs)
+			jStep, endpTrick = 1, 0
+		}
+		p := self.labelEnd[b]
+		for j != 0 {
+			self.label[self.endpoint[p^1]] = 0
+			self.label[self.endpoint[cyclicAt(endps, j-endpTrick)^endpTrick^1]] = 0
+			self.assignLabel(self.endpoint[p^1], 2, p)
+			self.allowEdge[cyclicAt(endps, j-endpTrick)/2] = true
+			j += jStep
+			p = cyclicAt(endps, j-endpTrick) ^ endpTrick
+			self.allowEdge[p/2] = true
+			j += jStep
+		}
+		// 花根所在的子花标记为奇数层, 不经过它的配偶
+		bv := cyclicAt(childs, j)
+		self.label[self.endpoint[p^1]], self.label[bv] = 2, 2
+		self.labelEnd[self.endpoint[p^1]], self.labelEnd[bv] = p, p
+		self.bestEdge[bv] = -1
+		j += jStep
+		for cyclicAt(childs, j) != entryChild {
+			bv := cyclicAt(childs, j)
+			if self.label[bv] == 1 {
+				j += jStep
+				continue
+			}
+			reached := -1
+			self.leaves(bv, func(v int) {
+				if reached < 0 && self.label[v] != 0 {
+					reached = v
+				}
+			})
+			if reached >= 0 {
+				self.label[reached] = 0
+				self.label[self.endpoint[self.mate[self.blossomBase[bv]]]] = 0
+				self.assignLabel(reached, 2, self.labelEnd[reached])
+			}
+			j += jStep
+		}
+	}
+	self.label[b], self.labelEnd[b] = -1, -1
+	self.blossomChilds[b], self.blossomEndps[b] = nil, nil
+	self.blossomBase[b] = -1
+	self.blossomBestEdges[b], self.bestEdge[b] = nil, -1
+	self.unused = append(self.unused, b)
+}
+
+// 沿花 b 内部的偶数长度路径翻转匹配, 让顶点 v 成为新的花根
+func (self *weightedBlossom) augmentBlossom(b, v int) {
+	t := v
+	for self.blossomParent[t] != b {
+		t = self.blossomParent[t]
+	}
+	if t >= self.n {
+		self.augmentBlossom(t, v)
+	}
+	childs, endps := self.blossomChilds[b], self.blossomEndps[b]
+	i := indexOf(childs, t)
+	j := i
+	jStep, endpTrick := -1, 1
+	if i&1 != 0 {
+		j -= len(childs)
+		jStep, endpTrick = 1, 0
+	}
+	for j != 0 {
+		j += jStep
+		t = cyclicAt(childs, j)
+		p := cyclicAt(endps, j-endpTrick) ^ endpTrick
+		if t >= self.n {
+			self.augmentBlossom(t, self.endpoint[p])
+		}
+		j += jStep
+		t = cyclicAt(childs, j)
+		if t >= self.n {
+			self.augmentBlossom(t, self.endpoint[p^1])
+		}
+		self.mate[self.endpoint[p]] = p ^ 1
+		self.mate[self.endpoint[p^1]] = p
+	}
+	// 旋转子花列表, 让新的花根所在的子花排在最前面
+	self.blossomChilds[b] = append(append([]int(nil), childs[i:]...), childs[:i]...)
+	self.blossomEndps[b] = append(append([]int(nil), endps[i:]...), endps[:i]...)
+	self.blossomBase[b] = self.blossomBase[self.blossomChilds[b][0]]
+}
+
+// 沿经过边 k 的增广路翻转匹配
+func (self *weightedBlossom) augmentMatching(k int) {
+	v, w := self.edges[k].V, self.edges[k].W
+	for _, sp := range [][2]int{{v, 2*k + 1}, {w, 2 * k}} {
+		s, p := sp[0], sp[1]
+		for {
+			bs := self.inBlossom[s]
+			if bs >= self.n {
+				self.augmentBlossom(bs, s)
+			}
+			self.mate[s] = p
+			if self.labelEnd[bs] == -1 {
+				break
+			}
+			t := self.endpoint[self.labelEnd[bs]]
+			bt := self.inBlossom[t]
+			s = self.endpoint[self.labelEnd[bt]]
+			j := self.endpoint[self.labelEnd[bt]^1]
+			if bt >= self.n {
+				self.augmentBlossom(bt, j)
+			}
+			self.mate[j] = self.labelEnd[bt]
+			p = self.labelEnd[bt] ^ 1
+		}
+	}
+}
+
+func (self *weightedBlossom) solve() {
+	n := self.n
+	for stage := 0; stage < n; stage++ {
+		for x := range self.label {
+			self.label[x], self.bestEdge[x] = 0, -1
+		}
+		for x := n; x < 2*n; x++ {
+			self.blossomBestEdges[x] = nil
+		}
+		for k := range self.allowEdge {
+			self.allowEdge[k] = false
+		}
+		self.queue = self.queue[:0]
+		for v := 0; v < n; v++ {
+			if self.mate[v] == -1 && self.label[self.inBlossom[v]] == 0 {
+				self.assignLabel(v, 1, -1)
+			}
+		}
+		augmented := false
+		for {
+			for len(self.queue) > 0 && !augmented {
+				v := self.queue[len(self.queue)-1]
+				self.queue = self.queue[:len(self.queue)-1]
+				for _, p := range self.neighbEnd[v] {
+					k, w := p/2, self.endpoint[p]
+					if self.inBlossom[v] == self.inBlossom[w] {
+						continue
+					}
+					kSlack := 0.0
+					if !self.allowEdge[k] {
+						if kSlack = self.slack(k); kSlack <= 0 {
+							self.allowEdge[k] = true
+						}
+					}
+					if self.allowEdge[k] {
+						if self.label[self.inBlossom[w]] == 0 {
+							self.assignLabel(w, 2, p^1)
+						} else if self.label[self.inBlossom[w]] == 1 {
+							if base := self.scanBlossom(v, w); base >= 0 {
+								self.addBlossom(base, k)
+							} else {
+								self.augmentMatching(k)
+								augmented = true
+								break
+							}
+						} else if self.label[w] == 0 {
+							// w 在奇数层花里但自己还没有标记
+							self.label[w], self.labelEnd[w] = 2, p^1
+						}
+					} else if self.label[self.inBlossom[w]] == 1 {
+						b := self.inBlossom[v]
+						if self.bestEdge[b] == -1 || kSlack < self.slack(self.bestEdge[b]) {
+							self.bestEdge[b] = k
+						}
+					} else if self.label[w] == 0 {
+						if self.bestEdge[w] == -1 || kSlack < self.slack(self.bestEdge[w]) {
+							self.bestEdge[w] = k
+						}
+					}
+				}
+			}
+			if augmented {
+				break
+			}
+			// 调整对偶变量, deltaType 表示是哪种情况限制了调整量
+			deltaType, delta, deltaEdge, deltaBlossom := -1, 0.0, -1, -1
+			if !self.maxCardinality {
+				deltaType, delta = 1, self.dualVar[0]
+				for v := 1; v < n; v++ {
+					if self.dualVar[v] < delta {
+						delta = self.dualVar[v]
+					}
+				}
+			}
+			for v := 0; v < n; v++ {
+				if self.label[self.inBlossom[v]] == 0 && self.bestEdge[v] != -1 {
+					if d := self.slack(self.bestEdge[v]); deltaType == -1 || d < delta {
+						deltaType, delta, deltaEdge = 2, d, self.bestEdge[v]
+					}
+				}
+			}
+			for b := 0; b < 2*n; b++ {
+				if self.blossomParent[b] == -1 && self.label[b] == 1 && self.bestEdge[b] != -1 {
+					if d := self.slack(self.bestEdge[b]) / 2; deltaType == -1 || d < delta {
+						deltaType, delta, deltaEdge = 3, d, self.bestEdge[b]
+					}
+				}
+			}
+			for b := n; b < 2*n; b++ {
+				if self.blossomBase[b] >= 0 && self.blossomParent[b] == -1 && self.label[b] == 2 &&
+					(deltaType == -1 || self.dualVar[b] < delta) {
+					deltaType, delta, deltaBlossom = 4, self.dualVar[b], b
+				}
+			}
+			if deltaType == -1 {
+				// 求最大基数匹配时已经没有增广路了, 最后调整一次让对偶变量保持最优
+				deltaType, delta = 1, self.dualVar[0]
+				for v := 1; v < n; v++ {
+					if self.dualVar[v] < delta {
+						delta = self.dualVar[v]
+					}
+				}
+				if delta < 0 {
+					delta = 0
+				}
+			}
+			for v := 0; v < n; v++ {
+				switch self.label[self.inBlossom[v]] {
+				case 1:
+					self.dualVar[v] -= delta
+				case 2:
+					self.dualVar[v] += delta
+				}
+			}
+			for b := n; b < 2*n; b++ {
+				if self.blossomBase[b] >= 0 && self.blossomParent[b] == -1 {
+					switch self.label[b] {
+					case 1:
+						self.dualVar[b] += delta
+					case 2:
+						self.dualVar[b] -= delta
+					}
+				}
+			}
+			if deltaType == 1 {
+				break // 没有可以继续的了
+			}
+			switch deltaType {
+			case 2:
+				self.allowEdge[deltaEdge] = true
+				i := self.edges[deltaEdge].V
+				if self.label[self.inBlossom[i]] == 0 {
+					i = self.edges[deltaEdge].W
+				}
+				self.queue = append(self.queue, i)
+			case 3:
+				self.allowEdge[deltaEdge] = true
+				self.queue = append(self.queue, self.edges[deltaEdge].V)
+			case 4:
+				self.expandBlossom(deltaBlossom, false)
+			}
+		}
+		if !augmented {
+			break
+		}
+		// 一个阶段结束, 展开对偶变量为 0 的外层偶数层花
+		for b := n; b < 2*n; b++ {
+			if self.blossomParent[b] == -1 && self.blossomBase[b] >= 0 && self.label[b] == 1 && self.dualVar[b] == 0 {
+				self.expandBlossom(b, true)
+			}
+		}
+	}
+}
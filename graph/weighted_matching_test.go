@@ -0,0 +1,85 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestWeightedBlossomMatching(t *testing.T) {
+	// 路径 0-1-2-3, 中间的边最重, 但两端的边加起来更重
+	g := NewEdgeWeightedGraph(4)
+	g.AddEdge(Edge{0, 1, 5})
+	g.AddEdge(Edge{1, 2, 8})
+	g.AddEdge(Edge{2, 3, 5})
+	m := NewWeightedBlossomMatching(g, false)
+	assertMatching(t, g, m)
+	assert.Equal(t, 10.0, m.Weight())
+	assert.Equal(t, 1, m.Mate(0))
+
+	// 只有负权边时不匹配更好, 但要求最大基数时必须匹配
+	neg := NewEdgeWeightedGraph(2)
+	neg.AddEdge(Edge{0, 1, -1})
+	assert.Equal(t, 0, NewWeightedBlossomMatching(neg, false).Size())
+	assert.Equal(t, 1, NewWeightedBlossomMatching(neg, true).Size())
+
+	// 需要收缩花的例子: 三角形 0-1-2 外接 3 和 4
+	b := NewEdgeWeightedGraph(6)
+	for _, e := range []Edge{{0, 1, 6}, {1, 2, 6}, {2, 0, 6}, {0, 3, 4}, {1, 4, 4}, {2, 5, 1}} {
+		b.AddEdge(e)
+	}
+	m = NewWeightedBlossomMatching(b, false)
+	assertMatching(t, b, m)
+	assert.Equal(t, 10.0, m.Weight())
+	assert.Equal(t, 0.0, NewWeightedBlossomMatching(NewEdgeWeightedGraph(0), true).Weight())
+}
+
+func TestWeightedBlossomMatchingRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 300; i++ {
+		n := 1 + r.Intn(10)
+		g := NewEdgeWeightedGraph(n)
+		for j := r.Intn(3 * n); j > 0; j-- {
+			g.AddEdge(Edge{r.Intn(n), r.Intn(n), float64(r.Intn(30) - 5)})
+		}
+		for _, maxCardinality := range []bool{false, true} {
+			m := NewWeightedBlossomMatching(g, maxCardinality)
+			assertMatching(t, g, m)
+			size, weight := bruteWeightedMatching(g, maxCardinality)
+			if maxCardinality {
+				assert.Equal(t, size, m.Size())
+			}
+			assert.InDelta(t, weight, m.Weight(), 1e-9, "case %d", i)
+		}
+	}
+}
+
+// 枚举所有匹配; maxCardinality 时先比较边数
+func bruteWeightedMatching(graph WeightedGraph, maxCardinality bool) (int, float64) {
+	edges := graph.Edges()
+	bestSize, bestWeight := 0, 0.0
+	used := make([]bool, graph.V())
+	var dfs func(i, size int, weight float64)
+	dfs = func(i, size int, weight float64) {
+		if i == len(edges) {
+			if maxCardinality && size != bestSize {
+				if size > bestSize {
+					bestSize, bestWeight = size, weight
+				}
+				return
+			}
+			if weight > bestWeight {
+				bestSize, bestWeight = size, weight
+			}
+			return
+		}
+		dfs(i+1, size, weight)
+		if e := edges[i]; e.V != e.W && !used[e.V] && !used[e.W] {
+			used[e.V], used[e.W] = true, true
+			dfs(i+1, size+1, weight+e.Weight)
+			used[e.V], used[e.W] = false, false
+		}
+	}
+	dfs(0, 0, 0)
+	return bestSize, bestWeight
+}
@@ -0,0 +1,45 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEdgeWeightedGraph(t *testing.T) {
+	g := NewEdgeWeightedGraph(4)
+	g.AddEdge(Edge{0, 1, 0.5})
+	g.AddEdge(Edge{1, 2, 1.5})
+	g.AddEdge(Edge{2, 2, 2})
+	g.AddEdge(Edge{0, 1, 3})
+	t.Log(g)
+	assert.Equal(t, 4, g.E())
+	assert.Equal(t, 3, len(g.Adj(1)))
+	assert.Equal(t, 2, len(g.Adj(2)))
+	assert.Equal(t, 4, len(g.Edges()))
+	assert.Equal(t, 0, g.Adj(1)[0].Other(1))
+
+	w := NewEdgeWeightedGraphByGraph(NewGraphByData(data1))
+	assert.Equal(t, 6, w.E())
+	for _, e := range w.Edges() {
+		assert.Equal(t, 1.0, e.Weight)
+	}
+}
+
+func TestEdgeWeightedDigraph(t *testing.T) {
+	g := NewEdgeWeightedDigraph(3)
+	g.AddEdge(DirectedEdge{0, 1, 0.5})
+	g.AddEdge(DirectedEdge{1, 2, 1.5})
+	g.AddEdge(DirectedEdge{2, 2, 2})
+	g.AddEdge(DirectedEdge{0, 1, 3})
+	t.Log(g)
+	assert.Equal(t, 4, g.E())
+	assert.Equal(t, 2, len(g.Adj(0)))
+	assert.Equal(t, 1, len(g.Adj(2)))
+	assert.Equal(t, 4, len(g.Edges()))
+
+	w := NewEdgeWeightedDigraphByDigraph(dag)
+	assert.Equal(t, dag.E(), w.E())
+	for _, e := range w.Edges() {
+		assert.Equal(t, 1.0, e.Weight)
+	}
+}